@@ -22,6 +22,7 @@ import (
 
 	"github.com/chenmingyong0423/go-mongox/v2/callback"
 	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/softdelete"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
@@ -44,6 +45,7 @@ type Aggregator[T any] struct {
 	modelHook   any
 	beforeHooks []beforeHookFn
 	afterHooks  []afterHookFn
+	unscoped    bool
 }
 
 func NewAggregator[T any](collection *mongo.Collection, dbCallbacks *callback.Callback, fields []*field.Filed) *Aggregator[T] {
@@ -73,17 +75,30 @@ func (a *Aggregator[T]) Pipeline(pipeline any) *Aggregator[T] {
 	return a
 }
 
+// Unscoped makes the aggregation include the soft deleted documents
+func (a *Aggregator[T]) Unscoped() *Aggregator[T] {
+	a.unscoped = true
+	return a
+}
+
+// scopedPipeline returns the pipeline sent to the collection, which starts with a $match stage
+// excluding the soft deleted documents unless Unscoped is called
+func (a *Aggregator[T]) scopedPipeline() any {
+	return softdelete.ScopeLivePipeline(a.fields, a.pipeline, a.unscoped)
+}
+
 func (a *Aggregator[T]) Aggregate(ctx context.Context, opts ...options.Lister[options.AggregateOptions]) ([]*T, error) {
 	currentTime := time.Now()
-	globalOpContext := operation.NewOpContext(a.collection, operation.WithPipeline(a.pipeline), operation.WithMongoOptions(opts), operation.WithModelHook(a.modelHook), operation.WithStartTime(currentTime), operation.WithFields(a.fields))
-	opContext := NewOpContext(a.collection, a.pipeline, WithMongoOptions(opts), WithModelHook(a.modelHook), WithStartTime(currentTime), WithFields(a.fields))
+	pipeline := a.scopedPipeline()
+	globalOpContext := operation.NewOpContext(a.collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithPipeline(pipeline), operation.WithMongoOptions(opts), operation.WithModelHook(a.modelHook), operation.WithStartTime(currentTime), operation.WithFields(a.fields))
+	opContext := NewOpContext(a.collection, pipeline, WithMongoOptions(opts), WithModelHook(a.modelHook), WithStartTime(currentTime), WithFields(a.fields))
 
 	err := a.preActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeAggregate)
 	if err != nil {
 		return nil, err
 	}
 
-	cursor, err := a.collection.Aggregate(ctx, a.pipeline, opts...)
+	cursor, err := a.collection.Aggregate(ctx, pipeline, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -112,15 +127,16 @@ func (a *Aggregator[T]) Aggregate(ctx context.Context, opts ...options.Lister[op
 func (a *Aggregator[T]) AggregateWithParse(ctx context.Context, result any, opts ...options.Lister[options.AggregateOptions]) error {
 
 	currentTime := time.Now()
-	globalOpContext := operation.NewOpContext(a.collection, operation.WithPipeline(a.pipeline), operation.WithMongoOptions(opts), operation.WithModelHook(a.modelHook), operation.WithStartTime(currentTime), operation.WithFields(a.fields))
-	opContext := NewOpContext(a.collection, a.pipeline, WithMongoOptions(opts), WithModelHook(a.modelHook), WithStartTime(currentTime), WithFields(a.fields))
+	pipeline := a.scopedPipeline()
+	globalOpContext := operation.NewOpContext(a.collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithPipeline(pipeline), operation.WithMongoOptions(opts), operation.WithModelHook(a.modelHook), operation.WithStartTime(currentTime), operation.WithFields(a.fields))
+	opContext := NewOpContext(a.collection, pipeline, WithMongoOptions(opts), WithModelHook(a.modelHook), WithStartTime(currentTime), WithFields(a.fields))
 
 	err := a.preActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeAggregate)
 	if err != nil {
 		return err
 	}
 
-	cursor, err := a.collection.Aggregate(ctx, a.pipeline, opts...)
+	cursor, err := a.collection.Aggregate(ctx, pipeline, opts...)
 	if err != nil {
 		return err
 	}
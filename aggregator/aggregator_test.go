@@ -28,6 +28,7 @@ import (
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 
 	"github.com/chenmingyong0423/go-mongox/v2/callback"
+	"github.com/chenmingyong0423/go-mongox/v2/field"
 	"github.com/chenmingyong0423/go-mongox/v2/operation"
 	"go.uber.org/mock/gomock"
 )
@@ -306,3 +307,96 @@ func TestAggregator_CorrectOpTypes(t *testing.T) {
 		assert.False(t, insertHookCalled, "Insert hook should not be called during aggregation")
 	})
 }
+
+type softDeleteUser struct {
+	ID        bson.ObjectID `bson:"_id,omitempty"`
+	Name      string        `bson:"name"`
+	DeletedAt time.Time     `bson:"deleted_at,omitempty"`
+}
+
+func TestAggregator_SoftDeleteScope(t *testing.T) {
+	client, err := mongo.Connect()
+	assert.NoError(t, err)
+	collection := client.Database("db-test").Collection("test_user")
+	errCallback := errors.New("callback error")
+	notDeleted := bson.D{{Key: "$match", Value: bson.D{{Key: "deleted_at", Value: bson.D{{Key: "$in", Value: bson.A{nil, time.Time{}}}}}}}}
+	sort := bson.D{{Key: "$sort", Value: bson.D{{Key: "name", Value: 1}}}}
+	geoNear := bson.D{{Key: "$geoNear", Value: bson.D{{Key: "near", Value: bson.A{0, 0}}}}}
+
+	testCases := []struct {
+		name     string
+		pipeline any
+		unscoped bool
+		call     func(a *Aggregator[softDeleteUser]) error
+		want     any
+	}{
+		{
+			name:     "aggregate",
+			pipeline: mongo.Pipeline{sort},
+			call: func(a *Aggregator[softDeleteUser]) error {
+				_, err := a.Aggregate(context.Background())
+				return err
+			},
+			want: mongo.Pipeline{notDeleted, sort},
+		},
+		{
+			name:     "aggregate with parse",
+			pipeline: mongo.Pipeline{sort},
+			call: func(a *Aggregator[softDeleteUser]) error {
+				var result []softDeleteUser
+				return a.AggregateWithParse(context.Background(), &result)
+			},
+			want: mongo.Pipeline{notDeleted, sort},
+		},
+		{
+			name:     "the stage which must be the first one",
+			pipeline: mongo.Pipeline{geoNear, sort},
+			call: func(a *Aggregator[softDeleteUser]) error {
+				_, err := a.Aggregate(context.Background())
+				return err
+			},
+			want: mongo.Pipeline{geoNear, notDeleted, sort},
+		},
+		{
+			name:     "unscoped aggregate",
+			pipeline: mongo.Pipeline{sort},
+			unscoped: true,
+			call: func(a *Aggregator[softDeleteUser]) error {
+				_, err := a.Aggregate(context.Background())
+				return err
+			},
+			want: mongo.Pipeline{sort},
+		},
+		{
+			name:     "unscoped aggregate with parse",
+			pipeline: mongo.Pipeline{sort},
+			unscoped: true,
+			call: func(a *Aggregator[softDeleteUser]) error {
+				var result []softDeleteUser
+				return a.AggregateWithParse(context.Background(), &result)
+			},
+			want: mongo.Pipeline{sort},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dbCallbacks := callback.InitializeCallbacks()
+			var pipeline any
+			dbCallbacks.Register(operation.OpTypeBeforeAggregate, "test", func(_ context.Context, opCtx *operation.OpContext, _ ...any) error {
+				pipeline = opCtx.Pipeline
+				return errCallback
+			})
+			a := NewAggregator[softDeleteUser](collection, dbCallbacks, field.ParseFields(softDeleteUser{})).Pipeline(tc.pipeline)
+			if tc.unscoped {
+				a = a.Unscoped()
+			}
+
+			assert.Equal(t, errCallback, tc.call(a))
+			assert.Equal(t, tc.want, pipeline)
+		})
+	}
+
+	// the models without a soft delete field are not scoped
+	a := NewAggregator[TestUser](collection, nil, field.ParseFields(TestUser{})).Pipeline(mongo.Pipeline{sort})
+	assert.Equal(t, mongo.Pipeline{sort}, a.scopedPipeline())
+}
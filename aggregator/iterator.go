@@ -0,0 +1,88 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregator
+
+import (
+	"context"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Iterator iterates over the results of the aggregation one at a time,
+// so that the results are never loaded into memory all together
+type Iterator[T any] struct {
+	aggregator *Aggregator[T]
+	cursor     *mongo.Cursor
+
+	globalOpContext *operation.OpContext
+	opContext       *OpContext
+}
+
+// Iter executes the aggregation and returns an iterator over the results.
+// The beforeAggregate callbacks are executed once, and the afterAggregate callbacks are executed for every result decoded.
+// The iterator must be closed after use.
+func (a *Aggregator[T]) Iter(ctx context.Context, opts ...options.Lister[options.AggregateOptions]) (*Iterator[T], error) {
+	currentTime := time.Now()
+	pipeline := a.scopedPipeline()
+	globalOpContext := operation.NewOpContext(a.collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithPipeline(pipeline), operation.WithMongoOptions(opts), operation.WithModelHook(a.modelHook), operation.WithStartTime(currentTime), operation.WithFields(a.fields))
+	opContext := NewOpContext(a.collection, pipeline, WithMongoOptions(opts), WithModelHook(a.modelHook), WithStartTime(currentTime), WithFields(a.fields))
+
+	err := a.preActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeAggregate)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := a.collection.Aggregate(ctx, pipeline, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Iterator[T]{aggregator: a, cursor: cursor, globalOpContext: globalOpContext, opContext: opContext}, nil
+}
+
+// Next moves to the next result, it returns false when there are no more results or an error occurs
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	return it.cursor.Next(ctx)
+}
+
+// Decode decodes the current result and executes the afterAggregate callbacks and hooks for it
+func (it *Iterator[T]) Decode(ctx context.Context) (*T, error) {
+	t := new(T)
+	if err := it.cursor.Decode(t); err != nil {
+		return nil, err
+	}
+
+	globalOpContext, opContext := *it.globalOpContext, *it.opContext
+	globalOpContext.Result = it.cursor
+	globalOpContext.Doc = t
+	opContext.Result = it.cursor
+	opContext.Doc = t
+	if err := it.aggregator.postActionHandler(ctx, &globalOpContext, &opContext, operation.OpTypeAfterAggregate); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Err returns the last error of the cursor
+func (it *Iterator[T]) Err() error {
+	return it.cursor.Err()
+}
+
+func (it *Iterator[T]) Close(ctx context.Context) error {
+	return it.cursor.Close(ctx)
+}
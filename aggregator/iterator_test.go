@@ -0,0 +1,85 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chenmingyong0423/go-mongox/v2/callback"
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func newTestIterator(t *testing.T, a *Aggregator[TestUser], names ...string) *Iterator[TestUser] {
+	docs := make([]any, 0, len(names))
+	for _, name := range names {
+		docs = append(docs, bson.D{{Key: "name", Value: name}})
+	}
+	cursor, err := mongo.NewCursorFromDocuments(docs, nil, nil)
+	require.NoError(t, err)
+	return &Iterator[TestUser]{
+		aggregator:      a,
+		cursor:          cursor,
+		globalOpContext: operation.NewOpContext(a.collection),
+		opContext:       NewOpContext(a.collection, nil),
+	}
+}
+
+func TestIterator_Decode(t *testing.T) {
+	var found []any
+	callbacks := callback.InitializeCallbacks()
+	callbacks.Register(operation.OpTypeAfterAggregate, "record", func(_ context.Context, opCtx *operation.OpContext, _ ...any) error {
+		found = append(found, opCtx.Doc)
+		return nil
+	})
+	a := NewAggregator[TestUser](&mongo.Collection{}, callbacks, nil)
+	var hooked []any
+	a.RegisterAfterHooks(func(_ context.Context, opContext *OpContext, _ ...any) error {
+		hooked = append(hooked, opContext.Doc)
+		return nil
+	})
+
+	it := newTestIterator(t, a, "cmy", "chenmingyong")
+	var users []*TestUser
+	for it.Next(context.Background()) {
+		user, err := it.Decode(context.Background())
+		require.NoError(t, err)
+		users = append(users, user)
+	}
+	require.NoError(t, it.Err())
+	require.NoError(t, it.Close(context.Background()))
+
+	assert.Equal(t, []*TestUser{{Name: "cmy"}, {Name: "chenmingyong"}}, users)
+	assert.Equal(t, []any{users[0], users[1]}, found)
+	assert.Equal(t, []any{users[0], users[1]}, hooked)
+}
+
+func TestIterator_Decode_HookError(t *testing.T) {
+	a := NewAggregator[TestUser](&mongo.Collection{}, callback.InitializeCallbacks(), nil)
+	a.RegisterAfterHooks(func(_ context.Context, _ *OpContext, _ ...any) error {
+		return errors.New("after hook error")
+	})
+
+	it := newTestIterator(t, a, "cmy")
+	require.True(t, it.Next(context.Background()))
+	user, err := it.Decode(context.Background())
+	assert.Equal(t, errors.New("after hook error"), err)
+	assert.Nil(t, user)
+}
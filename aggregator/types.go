@@ -37,6 +37,9 @@ type OpContext struct {
 	ModelHook    any
 	StartTime    time.Time
 
+	// Doc is the document decoded by the iterator, it is nil for the other operations
+	Doc any
+
 	Result any
 }
 
@@ -84,6 +87,12 @@ func WithStartTime(startTime time.Time) OpContextOption {
 	}
 }
 
+func WithDoc(doc any) OpContextOption {
+	return func(opContext *OpContext) {
+		opContext.Doc = doc
+	}
+}
+
 func WithResult(result any) OpContextOption {
 	return func(opContext *OpContext) {
 		opContext.Result = result
@@ -15,6 +15,7 @@
 package aggregation
 
 import (
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
@@ -43,13 +44,20 @@ type Builder struct {
 	condBuilder
 	accumulatorsBuilder
 
-	d bson.D
+	d   bson.D
+	err []error
 }
 
 func (b *Builder) Build() bson.D {
 	return b.d
 }
 
+// BuildE is the same as Build, besides it reports the invalid arguments passed to the builder,
+// such as a literal zero divisor of $divide. The errors wrap ErrInvalidArgument.
+func (b *Builder) BuildE() (bson.D, error) {
+	return b.d, utils.JoinErrors(b.err...)
+}
+
 func (b *Builder) KeyValue(key string, value any) *Builder {
 	b.d = append(b.d, bson.E{Key: key, Value: value})
 	return b
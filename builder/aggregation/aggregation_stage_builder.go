@@ -15,12 +15,14 @@
 package aggregation
 
 import (
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 type StageBuilder struct {
 	pipeline mongo.Pipeline
+	err      []error
 }
 
 func NewStageBuilder() *StageBuilder {
@@ -37,7 +39,16 @@ func (b *StageBuilder) Set(value any) *StageBuilder {
 	return b
 }
 
+// Unset removes the fields, which is also a stage of the pipeline-style updates
+func (b *StageBuilder) Unset(fields ...string) *StageBuilder {
+	b.pipeline = append(b.pipeline, bson.D{bson.E{Key: StageUnsetOp, Value: fields}})
+	return b
+}
+
 func (b *StageBuilder) Bucket(groupBy any, boundaries []any, opt *BucketOptions) *StageBuilder {
+	if len(boundaries) < 2 {
+		b.addErr("%s takes at least 2 boundaries, got %d", StageBucketOp, len(boundaries))
+	}
 	d := bson.D{
 		bson.E{Key: StageGroupByOp, Value: groupBy},
 		bson.E{Key: StageBoundariesOp, Value: boundaries},
@@ -55,6 +66,9 @@ func (b *StageBuilder) Bucket(groupBy any, boundaries []any, opt *BucketOptions)
 }
 
 func (b *StageBuilder) BucketAuto(groupBy any, buckets int, opt *BucketAutoOptions) *StageBuilder {
+	if buckets <= 0 {
+		b.addErr("the buckets of %s must be positive, got %d", StageBucketAutoOp, buckets)
+	}
 	d := bson.D{
 		bson.E{Key: StageGroupByOp, Value: groupBy},
 		bson.E{Key: StageBucketsOp, Value: buckets},
@@ -94,16 +108,23 @@ func (b *StageBuilder) Project(value any) *StageBuilder {
 }
 
 func (b *StageBuilder) Limit(limit int64) *StageBuilder {
+	if limit <= 0 {
+		b.addErr("the limit of %s must be positive, got %d", StageLimitOp, limit)
+	}
 	b.pipeline = append(b.pipeline, bson.D{bson.E{Key: StageLimitOp, Value: limit}})
 	return b
 }
 
 func (b *StageBuilder) Skip(skip int64) *StageBuilder {
+	if skip < 0 {
+		b.addErr("the skip of %s must not be negative, got %d", StageSkipOp, skip)
+	}
 	b.pipeline = append(b.pipeline, bson.D{bson.E{Key: StageSkipOp, Value: skip}})
 	return b
 }
 
 func (b *StageBuilder) Unwind(path string, opt *UnWindOptions) *StageBuilder {
+	b.checkFieldPath(StageUnwindOp, path)
 	if opt == nil {
 		b.pipeline = append(b.pipeline, bson.D{{Key: StageUnwindOp, Value: path}})
 	} else {
@@ -135,11 +156,19 @@ func (b *StageBuilder) SortByCount(expression any) *StageBuilder {
 }
 
 func (b *StageBuilder) Count(countName string) *StageBuilder {
+	b.checkCount(countName)
 	b.pipeline = append(b.pipeline, bson.D{bson.E{Key: StageCountOp, Value: countName}})
 	return b
 }
 
 func (b *StageBuilder) Lookup(from, as string, opt *LookUpOptions) *StageBuilder {
+	if as == "" {
+		b.addErr("the output field of %s must not be empty", StageLookUpOp)
+	}
+	if opt == nil {
+		b.addErr("%s requires the local and foreign fields or a pipeline", StageLookUpOp)
+		opt = &LookUpOptions{}
+	}
 	d := bson.D{bson.E{Key: "from", Value: from}}
 	if opt.LocalField != "" && opt.ForeignField != "" {
 		d = append(d, bson.E{Key: "localField", Value: opt.LocalField})
@@ -159,3 +188,9 @@ func (b *StageBuilder) Lookup(from, as string, opt *LookUpOptions) *StageBuilder
 func (b *StageBuilder) Build() mongo.Pipeline {
 	return b.pipeline
 }
+
+// BuildE is the same as Build, besides it reports the invalid arguments passed to the builder,
+// such as a non-positive $limit or an $unwind path without "$" prefix. The errors wrap ErrInvalidArgument.
+func (b *StageBuilder) BuildE() (mongo.Pipeline, error) {
+	return b.pipeline, utils.JoinErrors(b.err...)
+}
@@ -500,6 +500,11 @@ func TestStageBuilder_Unwind(t *testing.T) {
 	}
 }
 
+func TestStageBuilder_Unset(t *testing.T) {
+	assert.Equal(t, mongo.Pipeline{bson.D{bson.E{Key: "$unset", Value: []string{"age"}}}}, NewStageBuilder().Unset("age").Build())
+	assert.Equal(t, mongo.Pipeline{bson.D{bson.E{Key: "$unset", Value: []string{"age", "address.city"}}}}, NewStageBuilder().Unset("age", "address.city").Build())
+}
+
 func TestStageBuilder_ReplaceWith(t *testing.T) {
 	testCases := []struct {
 		name                string
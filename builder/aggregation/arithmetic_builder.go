@@ -62,6 +62,7 @@ func (b *arithmeticBuilder) CeilWithoutKey(expression any) *Builder {
 }
 
 func (b *arithmeticBuilder) Divide(key string, expressions ...any) *Builder {
+	b.parent.checkDivision(DivideOp, key, expressions)
 	e := bson.E{Key: DivideOp, Value: expressions}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.d = append(b.parent.d, bson.E{Key: key, Value: bson.D{e}})
@@ -70,6 +71,7 @@ func (b *arithmeticBuilder) Divide(key string, expressions ...any) *Builder {
 }
 
 func (b *arithmeticBuilder) DivideWithoutKey(expressions ...any) *Builder {
+	b.parent.checkDivision(DivideOp, "", expressions)
 	b.parent.d = append(b.parent.d, bson.E{Key: DivideOp, Value: expressions})
 	return b.parent
 }
@@ -140,6 +142,7 @@ func (b *arithmeticBuilder) Log10WithoutKey(numberExpression any) *Builder {
 }
 
 func (b *arithmeticBuilder) Mod(key string, expressions ...any) *Builder {
+	b.parent.checkDivision(ModOp, key, expressions)
 	e := bson.E{Key: ModOp, Value: expressions}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.d = append(b.parent.d, bson.E{Key: key, Value: bson.D{e}})
@@ -148,6 +151,7 @@ func (b *arithmeticBuilder) Mod(key string, expressions ...any) *Builder {
 }
 
 func (b *arithmeticBuilder) ModWithoutKey(expressions ...any) *Builder {
+	b.parent.checkDivision(ModOp, "", expressions)
 	b.parent.d = append(b.parent.d, bson.E{Key: ModOp, Value: expressions})
 	return b.parent
 }
@@ -205,6 +209,7 @@ func (b *arithmeticBuilder) SqrtWithoutKey(numberExpression any) *Builder {
 }
 
 func (b *arithmeticBuilder) Subtract(key string, expressions ...any) *Builder {
+	b.parent.checkSubtract(key, expressions)
 	e := bson.E{Key: SubtractOp, Value: expressions}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.d = append(b.parent.d, bson.E{Key: key, Value: bson.D{e}})
@@ -213,6 +218,7 @@ func (b *arithmeticBuilder) Subtract(key string, expressions ...any) *Builder {
 }
 
 func (b *arithmeticBuilder) SubtractWithoutKey(expressions ...any) *Builder {
+	b.parent.checkSubtract("", expressions)
 	b.parent.d = append(b.parent.d, bson.E{Key: SubtractOp, Value: expressions})
 	return b.parent
 }
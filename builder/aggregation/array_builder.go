@@ -88,6 +88,7 @@ func (b *arrayBuilder) SliceWithoutKey(array any, nElements int64) *Builder {
 }
 
 func (b *arrayBuilder) SliceWithPosition(key string, array any, position, nElements int64) *Builder {
+	b.parent.checkSlice(key, nElements)
 	e := bson.E{Key: SliceOp, Value: []any{array, position, nElements}}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.d = append(b.parent.d, bson.E{Key: key, Value: bson.D{e}})
@@ -96,6 +97,7 @@ func (b *arrayBuilder) SliceWithPosition(key string, array any, position, nEleme
 }
 
 func (b *arrayBuilder) SliceWithPositionWithoutKey(array any, position, nElements int64) *Builder {
+	b.parent.checkSlice("", nElements)
 	b.parent.d = append(b.parent.d, bson.E{Key: SliceOp, Value: []any{array, position, nElements}})
 	return b.parent
 }
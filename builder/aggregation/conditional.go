@@ -0,0 +1,36 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import "github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
+
+// If calls fn with the builder if cond is true, so that the optional stages don't break the chain,
+// such as If(req.Page > 0, func(b *StageBuilder) { b.Skip((req.Page - 1) * req.Size) })
+func (b *StageBuilder) If(cond bool, fn func(b *StageBuilder)) *StageBuilder {
+	if cond && fn != nil {
+		fn(b)
+	}
+	return b
+}
+
+// WhenNotZero calls fn with the builder if the value is not the zero value of its type
+func (b *StageBuilder) WhenNotZero(value any, fn func(b *StageBuilder)) *StageBuilder {
+	return b.If(!utils.IsZero(value), fn)
+}
+
+// WhenNotNil calls fn with the builder if the value is neither nil nor a nil pointer, map or slice
+func (b *StageBuilder) WhenNotNil(value any, fn func(b *StageBuilder)) *StageBuilder {
+	return b.If(!utils.IsNil(value), fn)
+}
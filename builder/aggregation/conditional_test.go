@@ -0,0 +1,43 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestStageBuilder_If(t *testing.T) {
+	build := func(page, size int64, sort bson.D, match *bson.D) mongo.Pipeline {
+		return NewStageBuilder().
+			WhenNotNil(match, func(b *StageBuilder) { b.Match(*match) }).
+			WhenNotZero(sort, func(b *StageBuilder) { b.Sort(sort) }).
+			If(page > 0, func(b *StageBuilder) { b.Skip((page - 1) * size).Limit(size) }).
+			Build()
+	}
+
+	assert.Equal(t, mongo.Pipeline{}, build(0, 10, nil, nil))
+
+	match := bson.D{{Key: "name", Value: "cmy"}}
+	assert.Equal(t, mongo.Pipeline{
+		{{Key: StageMatchOp, Value: match}},
+		{{Key: StageSortOp, Value: bson.D{{Key: "age", Value: -1}}}},
+		{{Key: StageSkipOp, Value: int64(10)}},
+		{{Key: StageLimitOp, Value: int64(10)}},
+	}, build(2, 10, bson.D{{Key: "age", Value: -1}}, &match))
+}
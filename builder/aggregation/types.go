@@ -112,6 +112,7 @@ const (
 	StageSkipOp        = "$skip"
 	StageSortByCountOp = "$sortByCount"
 	StageSortOp        = "$sort"
+	StageUnsetOp       = "$unset"
 	StageUnwindOp      = "$unwind"
 )
 
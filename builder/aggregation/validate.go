@@ -0,0 +1,77 @@
+// Copyright 2023 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
+)
+
+// ErrInvalidArgument is wrapped by the errors reported by BuildE
+var ErrInvalidArgument = utils.ErrInvalidArgument
+
+func invalidArgument(format string, args ...any) error {
+	return fmt.Errorf("%w: "+format, append([]any{ErrInvalidArgument}, args...)...)
+}
+
+// describe returns the description of the operator in the error messages
+func describe(op, key string) string {
+	if key == "" {
+		return op
+	}
+	return fmt.Sprintf("%s on %q", op, key)
+}
+
+// checkDivision reports the divisions without exactly a dividend and a divisor, or with a literal zero divisor
+func (b *Builder) checkDivision(op, key string, expressions []any) {
+	if len(expressions) != 2 {
+		b.err = append(b.err, invalidArgument("%s takes 2 expressions, got %d", describe(op, key), len(expressions)))
+		return
+	}
+	if divisor := expressions[1]; divisor != nil && utils.IsNumeric(divisor) && reflect.ValueOf(divisor).IsZero() {
+		b.err = append(b.err, invalidArgument("the divisor of %s must not be zero", describe(op, key)))
+	}
+}
+
+func (b *Builder) checkSubtract(key string, expressions []any) {
+	if len(expressions) != 2 {
+		b.err = append(b.err, invalidArgument("%s takes 2 expressions, got %d", describe(SubtractOp, key), len(expressions)))
+	}
+}
+
+func (b *Builder) checkSlice(key string, nElements int64) {
+	if nElements <= 0 {
+		b.err = append(b.err, invalidArgument("the number of elements of %s with a position must be positive, got %d", describe(SliceOp, key), nElements))
+	}
+}
+
+func (b *StageBuilder) addErr(format string, args ...any) {
+	b.err = append(b.err, invalidArgument(format, args...))
+}
+
+func (b *StageBuilder) checkFieldPath(op, path string) {
+	if !strings.HasPrefix(path, "$") || len(path) == 1 {
+		b.addErr("the path of %s must be a field path prefixed with \"$\", got %q", op, path)
+	}
+}
+
+func (b *StageBuilder) checkCount(name string) {
+	if name == "" || strings.HasPrefix(name, "$") || strings.Contains(name, ".") {
+		b.addErr("the name of %s must be a non-empty string without \"$\" prefix and \".\", got %q", StageCountOp, name)
+	}
+}
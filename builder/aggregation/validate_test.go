@@ -0,0 +1,112 @@
+// Copyright 2023 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder_BuildE(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *Builder
+		errs    int
+	}{
+		{
+			name:    "valid",
+			builder: NewBuilder().Divide("ratio", "$a", 2).ModWithoutKey("$a", "$b").Subtract("diff", "$a", "$b").SliceWithPosition("top", "$items", 0, 3),
+		},
+		{
+			name:    "zero divisor",
+			builder: NewBuilder().Divide("ratio", "$a", 0).ModWithoutKey("$a", 0.0),
+			errs:    2,
+		},
+		{
+			name:    "wrong number of expressions",
+			builder: NewBuilder().Divide("ratio", "$a").SubtractWithoutKey("$a", "$b", "$c"),
+			errs:    2,
+		},
+		{
+			name:    "non-positive number of elements",
+			builder: NewBuilder().SliceWithPositionWithoutKey("$items", 1, 0),
+			errs:    1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.builder.BuildE()
+			assert.Equal(t, tc.builder.Build(), got)
+			if tc.errs == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidArgument)
+			if tc.errs > 1 {
+				assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), tc.errs)
+			}
+		})
+	}
+}
+
+func TestStageBuilder_BuildE(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *StageBuilder
+		errs    int
+	}{
+		{
+			name: "valid",
+			builder: NewStageBuilder().Bucket("$age", []any{0, 18}, nil).BucketAuto("$age", 2, nil).Limit(1).Skip(0).
+				Unwind("$tags", nil).Count("total").Lookup("orders", "orders", &LookUpOptions{LocalField: "_id", ForeignField: "user_id"}),
+		},
+		{
+			name:    "invalid bucket",
+			builder: NewStageBuilder().Bucket("$age", []any{0}, nil).BucketAuto("$age", 0, nil),
+			errs:    2,
+		},
+		{
+			name:    "invalid limit and skip",
+			builder: NewStageBuilder().Limit(0).Skip(-1),
+			errs:    2,
+		},
+		{
+			name:    "invalid unwind and count",
+			builder: NewStageBuilder().Unwind("tags", nil).Count("$total"),
+			errs:    2,
+		},
+		{
+			name:    "invalid lookup",
+			builder: NewStageBuilder().Lookup("orders", "", nil),
+			errs:    2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.builder.BuildE()
+			assert.Equal(t, tc.builder.Build(), got)
+			if tc.errs == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidArgument)
+			if tc.errs > 1 {
+				assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), tc.errs)
+			}
+		})
+	}
+}
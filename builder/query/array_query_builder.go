@@ -157,8 +157,25 @@ func (b *arrayQueryBuilder) ElemMatch(key string, condition any) *Builder {
 	return b.parent
 }
 
+// ElemMatchFn appends an element with '$elemMatch' key and the conditions built by fn to the builder's data slice.
+// The conditions are on the fields of the embedded documents, such as ElemMatchFn("items", func(b *Builder) { b.Eq("name", "pen").Gt("qty", 1) }),
+// or on an empty key for the arrays of values, such as ElemMatchFn("scores", func(b *Builder) { b.Gte("", 80).Lt("", 85) }).
+func (b *arrayQueryBuilder) ElemMatchFn(key string, fn func(b *Builder)) *Builder {
+	condition := b.parent.build(fn)
+	var value any = condition
+	if len(condition) == 1 && condition[0].Key == "" {
+		value = condition[0].Value
+	}
+	e := bson.E{Key: ElemMatchOp, Value: value}
+	if !b.parent.tryMergeValue(key, e) {
+		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
+	}
+	return b.parent
+}
+
 // Size appends an element with '$size' key and given value to the builder's data slice.
 func (b *arrayQueryBuilder) Size(key string, size int) *Builder {
+	b.parent.checkSize(key, size)
 	e := bson.E{Key: SizeOp, Value: size}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -800,3 +800,47 @@ func Test_arrayQueryBuilder_Size(t *testing.T) {
 
 	assert.Equal(t, bson.D{{Key: "age", Value: bson.D{bson.E{Key: "$gt", Value: 18}, bson.E{Key: "$size", Value: 1}}}}, NewBuilder().Gt("age", 18).Size("age", 1).Build())
 }
+
+func Test_arrayQueryBuilder_ElemMatchFn(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *Builder
+		want    bson.D
+		wantErr error
+	}{
+		{
+			name:    "embedded documents",
+			builder: NewBuilder().ElemMatchFn("items", func(b *Builder) { b.Eq("name", "pen").Gt("qty", 1) }),
+			want: bson.D{{Key: "items", Value: bson.D{{Key: ElemMatchOp, Value: bson.D{
+				{Key: "name", Value: bson.D{{Key: EqOp, Value: "pen"}}},
+				{Key: "qty", Value: bson.D{{Key: GtOp, Value: 1}}},
+			}}}}},
+		},
+		{
+			name:    "values merged with the other conditions",
+			builder: NewBuilder().Size("scores", 3).ElemMatchFn("scores", func(b *Builder) { b.Gte("", 80).Lt("", 85) }),
+			want: bson.D{{Key: "scores", Value: bson.D{
+				{Key: SizeOp, Value: 3},
+				{Key: ElemMatchOp, Value: bson.D{{Key: GteOp, Value: 80}, {Key: LtOp, Value: 85}}},
+			}}},
+		},
+		{
+			name: "nested closures",
+			builder: NewBuilder().ElemMatchFn("items", func(b *Builder) {
+				b.OrFn(func(b *Builder) { b.Eq("name", "pen") }, func(b *Builder) { b.In("name") })
+			}),
+			want: bson.D{{Key: "items", Value: bson.D{{Key: ElemMatchOp, Value: bson.D{{Key: OrOp, Value: []any{
+				bson.D{{Key: "name", Value: bson.D{{Key: EqOp, Value: "pen"}}}},
+				bson.D{{Key: "name", Value: bson.D{{Key: InOp, Value: []any(nil)}}}},
+			}}}}}}},
+			wantErr: ErrInvalidArgument,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.builder.BuildE()
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
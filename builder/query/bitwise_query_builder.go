@@ -0,0 +1,54 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// bitwiseQueryBuilder builds the bitwise operators, whose bitmask is a non-negative integer mask,
+// a slice of bit positions, such as []int{1, 5}, or a bson.Binary
+type bitwiseQueryBuilder struct {
+	parent *Builder
+}
+
+// BitsAllClear appends an element with '$bitsAllClear' key and given bitmask to the builder's data slice.
+func (b *bitwiseQueryBuilder) BitsAllClear(key string, bitmask any) *Builder {
+	return b.appendBits(key, BitsAllClearOp, bitmask)
+}
+
+// BitsAllSet appends an element with '$bitsAllSet' key and given bitmask to the builder's data slice.
+func (b *bitwiseQueryBuilder) BitsAllSet(key string, bitmask any) *Builder {
+	return b.appendBits(key, BitsAllSetOp, bitmask)
+}
+
+// BitsAnyClear appends an element with '$bitsAnyClear' key and given bitmask to the builder's data slice.
+func (b *bitwiseQueryBuilder) BitsAnyClear(key string, bitmask any) *Builder {
+	return b.appendBits(key, BitsAnyClearOp, bitmask)
+}
+
+// BitsAnySet appends an element with '$bitsAnySet' key and given bitmask to the builder's data slice.
+func (b *bitwiseQueryBuilder) BitsAnySet(key string, bitmask any) *Builder {
+	return b.appendBits(key, BitsAnySetOp, bitmask)
+}
+
+func (b *bitwiseQueryBuilder) appendBits(key, op string, bitmask any) *Builder {
+	b.parent.checkBitmask(op, key, bitmask)
+	e := bson.E{Key: op, Value: bitmask}
+	if !b.parent.tryMergeValue(key, e) {
+		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
+	}
+	return b.parent
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func Test_bitwiseQueryBuilder(t *testing.T) {
+	binary := bson.Binary{Data: []byte{0x20}}
+	testCases := []struct {
+		name    string
+		builder *Builder
+		want    bson.D
+	}{
+		{
+			name:    "bits all set with positions",
+			builder: NewBuilder().BitsAllSet("permissions", []int{1, 5}),
+			want:    bson.D{{Key: "permissions", Value: bson.D{{Key: BitsAllSetOp, Value: []int{1, 5}}}}},
+		},
+		{
+			name:    "bits any set with mask",
+			builder: NewBuilder().BitsAnySet("permissions", 35),
+			want:    bson.D{{Key: "permissions", Value: bson.D{{Key: BitsAnySetOp, Value: 35}}}},
+		},
+		{
+			name:    "bits all clear with binary",
+			builder: NewBuilder().BitsAllClear("permissions", binary),
+			want:    bson.D{{Key: "permissions", Value: bson.D{{Key: BitsAllClearOp, Value: binary}}}},
+		},
+		{
+			name:    "merged bits any clear",
+			builder: NewBuilder().BitsAllSet("permissions", uint8(1)).BitsAnyClear("permissions", []any{0, int64(3)}),
+			want: bson.D{{Key: "permissions", Value: bson.D{
+				{Key: BitsAllSetOp, Value: uint8(1)},
+				{Key: BitsAnyClearOp, Value: []any{0, int64(3)}},
+			}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.builder.BuildE()
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func Test_bitwiseQueryBuilder_BuildE(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *Builder
+	}{
+		{name: "negative mask", builder: NewBuilder().BitsAllSet("permissions", -1)},
+		{name: "negative position", builder: NewBuilder().BitsAnySet("permissions", []int{1, -5})},
+		{name: "non-integer position", builder: NewBuilder().BitsAllClear("permissions", []any{1, "2"})},
+		{name: "float mask", builder: NewBuilder().BitsAnyClear("permissions", 1.5)},
+		{name: "nil mask", builder: NewBuilder().BitsAllSet("permissions", nil)},
+		{name: "uint64 overflow", builder: NewBuilder().BitsAllSet("permissions", uint64(1)<<63)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.builder.BuildE()
+			assert.ErrorIs(t, err, ErrInvalidArgument)
+		})
+	}
+}
@@ -15,6 +15,8 @@
 package query
 
 import (
+	"github.com/chenmingyong0423/go-mongox/v2/geo"
+
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
@@ -26,6 +28,27 @@ func And(conditions ...any) bson.D {
 	return bson.D{bson.E{Key: AndOp, Value: conditions}}
 }
 
+// BitsAllClear matches the values whose bits of the bitmask are all clear,
+// the bitmask is a non-negative integer mask, a slice of bit positions or a bson.Binary
+func BitsAllClear(key string, bitmask any) bson.D {
+	return bson.D{bson.E{Key: key, Value: bson.D{{Key: BitsAllClearOp, Value: bitmask}}}}
+}
+
+// BitsAllSet matches the values whose bits of the bitmask are all set, the bitmask is the same as BitsAllClear
+func BitsAllSet(key string, bitmask any) bson.D {
+	return bson.D{bson.E{Key: key, Value: bson.D{{Key: BitsAllSetOp, Value: bitmask}}}}
+}
+
+// BitsAnyClear matches the values having any of the bits of the bitmask clear, the bitmask is the same as BitsAllClear
+func BitsAnyClear(key string, bitmask any) bson.D {
+	return bson.D{bson.E{Key: key, Value: bson.D{{Key: BitsAnyClearOp, Value: bitmask}}}}
+}
+
+// BitsAnySet matches the values having any of the bits of the bitmask set, the bitmask is the same as BitsAllClear
+func BitsAnySet(key string, bitmask any) bson.D {
+	return bson.D{bson.E{Key: key, Value: bson.D{{Key: BitsAnySetOp, Value: bitmask}}}}
+}
+
 func ElemMatch(key string, cond any) bson.D {
 	return bson.D{bson.E{Key: key, Value: bson.D{{Key: ElemMatchOp, Value: cond}}}}
 }
@@ -42,6 +65,29 @@ func Expr(value any) bson.D {
 	return bson.D{bson.E{Key: ExprOp, Value: value}}
 }
 
+func GeoIntersects(key string, geometry geo.Geometry) bson.D {
+	return bson.D{bson.E{Key: key, Value: bson.D{{Key: GeoIntersectsOp, Value: bson.D{{Key: GeometryOp, Value: geometry}}}}}}
+}
+
+func GeoWithin(key string, area geo.Area) bson.D {
+	return bson.D{bson.E{Key: key, Value: bson.D{{Key: GeoWithinOp, Value: bson.D{{Key: GeometryOp, Value: area}}}}}}
+}
+
+// GeoWithinBox matches the legacy coordinate pairs within the box of the bottom left and the top right corners
+func GeoWithinBox(key string, bottomLeft, topRight geo.Position) bson.D {
+	return bson.D{bson.E{Key: key, Value: bson.D{{Key: GeoWithinOp, Value: bson.D{{Key: BoxOp, Value: []geo.Position{bottomLeft, topRight}}}}}}}
+}
+
+// GeoWithinCenter matches the legacy coordinate pairs within the circle, the radius is measured in the units of the coordinates
+func GeoWithinCenter(key string, center geo.Position, radius float64) bson.D {
+	return bson.D{bson.E{Key: key, Value: bson.D{{Key: GeoWithinOp, Value: bson.D{{Key: CenterOp, Value: bson.A{center, radius}}}}}}}
+}
+
+// GeoWithinPolygon matches the legacy coordinate pairs within the polygon, which is closed implicitly
+func GeoWithinPolygon(key string, points ...geo.Position) bson.D {
+	return bson.D{bson.E{Key: key, Value: bson.D{{Key: GeoWithinOp, Value: bson.D{{Key: PolygonOp, Value: points}}}}}}
+}
+
 func Gt(key string, value any) bson.D {
 	return bson.D{bson.E{Key: key, Value: bson.D{{Key: GtOp, Value: value}}}}
 }
@@ -82,6 +128,16 @@ func Ne(key string, value any) bson.D {
 	return bson.D{bson.E{Key: key, Value: bson.D{{Key: NeOp, Value: value}}}}
 }
 
+// Near sorts the documents from the nearest to the farthest of the point, which requires a geospatial index
+func Near(key string, point geo.Point, opt *NearOptions) bson.D {
+	return bson.D{bson.E{Key: key, Value: bson.D{{Key: NearOp, Value: nearValue(point, opt)}}}}
+}
+
+// NearSphere is the same as Near, besides the distances are calculated on a sphere
+func NearSphere(key string, point geo.Point, opt *NearOptions) bson.D {
+	return bson.D{bson.E{Key: key, Value: bson.D{{Key: NearSphereOp, Value: nearValue(point, opt)}}}}
+}
+
 func Nor(conditions ...any) bson.D {
 	return bson.D{bson.E{Key: NorOp, Value: conditions}}
 }
@@ -17,6 +17,8 @@ package query
 import (
 	"testing"
 
+	"github.com/chenmingyong0423/go-mongox/v2/geo"
+
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
@@ -668,3 +670,87 @@ func TestSliceRanger(t *testing.T) {
 		})
 	}
 }
+
+func TestGeospatial(t *testing.T) {
+	point := geo.NewPoint(113.93, 22.53)
+	testCases := []struct {
+		name string
+		got  bson.D
+		want bson.D
+	}{
+		{
+			name: "near",
+			got:  Near("location", point, &NearOptions{MinDistance: 10, MaxDistance: 1000}),
+			want: bson.D{bson.E{Key: "location", Value: bson.D{{Key: "$near", Value: bson.D{{Key: "$geometry", Value: point}, {Key: "$maxDistance", Value: float64(1000)}, {Key: "$minDistance", Value: float64(10)}}}}}},
+		},
+		{
+			name: "near sphere",
+			got:  NearSphere("location", point, nil),
+			want: bson.D{bson.E{Key: "location", Value: bson.D{{Key: "$nearSphere", Value: bson.D{{Key: "$geometry", Value: point}}}}}},
+		},
+		{
+			name: "geo within",
+			got:  GeoWithin("area", geo.MultiPolygon{}),
+			want: bson.D{bson.E{Key: "area", Value: bson.D{{Key: "$geoWithin", Value: bson.D{{Key: "$geometry", Value: geo.MultiPolygon{}}}}}}},
+		},
+		{
+			name: "geo within box",
+			got:  GeoWithinBox("location", geo.Position{0, 0}, geo.Position{100, 100}),
+			want: bson.D{bson.E{Key: "location", Value: bson.D{{Key: "$geoWithin", Value: bson.D{{Key: "$box", Value: []geo.Position{{0, 0}, {100, 100}}}}}}}},
+		},
+		{
+			name: "geo within center",
+			got:  GeoWithinCenter("location", geo.Position{-74, 40.74}, 10),
+			want: bson.D{bson.E{Key: "location", Value: bson.D{{Key: "$geoWithin", Value: bson.D{{Key: "$center", Value: bson.A{geo.Position{-74, 40.74}, float64(10)}}}}}}},
+		},
+		{
+			name: "geo within polygon",
+			got:  GeoWithinPolygon("location", geo.Position{0, 0}, geo.Position{3, 6}, geo.Position{6, 0}),
+			want: bson.D{bson.E{Key: "location", Value: bson.D{{Key: "$geoWithin", Value: bson.D{{Key: "$polygon", Value: []geo.Position{{0, 0}, {3, 6}, {6, 0}}}}}}}},
+		},
+		{
+			name: "geo intersects",
+			got:  GeoIntersects("route", point),
+			want: bson.D{bson.E{Key: "route", Value: bson.D{{Key: "$geoIntersects", Value: bson.D{{Key: "$geometry", Value: point}}}}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.got)
+		})
+	}
+}
+
+func TestBitwise(t *testing.T) {
+	testCases := []struct {
+		name string
+		got  bson.D
+		want bson.D
+	}{
+		{
+			name: "bits all clear",
+			got:  BitsAllClear("permissions", 35),
+			want: bson.D{bson.E{Key: "permissions", Value: bson.D{{Key: "$bitsAllClear", Value: 35}}}},
+		},
+		{
+			name: "bits all set",
+			got:  BitsAllSet("permissions", []int{1, 5}),
+			want: bson.D{bson.E{Key: "permissions", Value: bson.D{{Key: "$bitsAllSet", Value: []int{1, 5}}}}},
+		},
+		{
+			name: "bits any clear",
+			got:  BitsAnyClear("permissions", bson.Binary{Data: []byte{0x30}}),
+			want: bson.D{bson.E{Key: "permissions", Value: bson.D{{Key: "$bitsAnyClear", Value: bson.Binary{Data: []byte{0x30}}}}}},
+		},
+		{
+			name: "bits any set",
+			got:  BitsAnySet("permissions", []int{0}),
+			want: bson.D{bson.E{Key: "permissions", Value: bson.D{{Key: "$bitsAnySet", Value: []int{0}}}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.got)
+		})
+	}
+}
@@ -47,6 +47,7 @@ func (b *comparisonQueryBuilder) Gte(key string, value any) *Builder {
 }
 
 func (b *comparisonQueryBuilder) In(key string, values ...any) *Builder {
+	b.parent.checkIn(key, len(values))
 	e := bson.E{Key: InOp, Value: values}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -55,6 +56,7 @@ func (b *comparisonQueryBuilder) In(key string, values ...any) *Builder {
 }
 
 func (b *comparisonQueryBuilder) InFloat32(key string, values ...float32) *Builder {
+	b.parent.checkIn(key, len(values))
 	e := bson.E{Key: InOp, Value: values}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -63,6 +65,7 @@ func (b *comparisonQueryBuilder) InFloat32(key string, values ...float32) *Build
 }
 
 func (b *comparisonQueryBuilder) InFloat64(key string, values ...float64) *Builder {
+	b.parent.checkIn(key, len(values))
 	e := bson.E{Key: InOp, Value: values}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -71,6 +74,7 @@ func (b *comparisonQueryBuilder) InFloat64(key string, values ...float64) *Build
 }
 
 func (b *comparisonQueryBuilder) InInt(key string, values ...int) *Builder {
+	b.parent.checkIn(key, len(values))
 	e := bson.E{Key: InOp, Value: values}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -79,6 +83,7 @@ func (b *comparisonQueryBuilder) InInt(key string, values ...int) *Builder {
 }
 
 func (b *comparisonQueryBuilder) InInt16(key string, values ...int16) *Builder {
+	b.parent.checkIn(key, len(values))
 	e := bson.E{Key: InOp, Value: values}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -87,6 +92,7 @@ func (b *comparisonQueryBuilder) InInt16(key string, values ...int16) *Builder {
 }
 
 func (b *comparisonQueryBuilder) InInt32(key string, values ...int32) *Builder {
+	b.parent.checkIn(key, len(values))
 	e := bson.E{Key: InOp, Value: values}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -95,6 +101,7 @@ func (b *comparisonQueryBuilder) InInt32(key string, values ...int32) *Builder {
 }
 
 func (b *comparisonQueryBuilder) InInt64(key string, values ...int64) *Builder {
+	b.parent.checkIn(key, len(values))
 	e := bson.E{Key: InOp, Value: values}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -103,6 +110,7 @@ func (b *comparisonQueryBuilder) InInt64(key string, values ...int64) *Builder {
 }
 
 func (b *comparisonQueryBuilder) InInt8(key string, values ...int8) *Builder {
+	b.parent.checkIn(key, len(values))
 	e := bson.E{Key: InOp, Value: values}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -111,6 +119,7 @@ func (b *comparisonQueryBuilder) InInt8(key string, values ...int8) *Builder {
 }
 
 func (b *comparisonQueryBuilder) InString(key string, values ...string) *Builder {
+	b.parent.checkIn(key, len(values))
 	e := bson.E{Key: InOp, Value: values}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -119,6 +128,7 @@ func (b *comparisonQueryBuilder) InString(key string, values ...string) *Builder
 }
 
 func (b *comparisonQueryBuilder) InUint(key string, values ...uint) *Builder {
+	b.parent.checkIn(key, len(values))
 	e := bson.E{Key: InOp, Value: values}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -127,6 +137,7 @@ func (b *comparisonQueryBuilder) InUint(key string, values ...uint) *Builder {
 }
 
 func (b *comparisonQueryBuilder) InUint16(key string, values ...uint16) *Builder {
+	b.parent.checkIn(key, len(values))
 	e := bson.E{Key: InOp, Value: values}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -135,6 +146,7 @@ func (b *comparisonQueryBuilder) InUint16(key string, values ...uint16) *Builder
 }
 
 func (b *comparisonQueryBuilder) InUint32(key string, values ...uint32) *Builder {
+	b.parent.checkIn(key, len(values))
 	e := bson.E{Key: InOp, Value: values}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -143,6 +155,7 @@ func (b *comparisonQueryBuilder) InUint32(key string, values ...uint32) *Builder
 }
 
 func (b *comparisonQueryBuilder) InUint64(key string, values ...uint64) *Builder {
+	b.parent.checkIn(key, len(values))
 	e := bson.E{Key: InOp, Value: values}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -151,6 +164,7 @@ func (b *comparisonQueryBuilder) InUint64(key string, values ...uint64) *Builder
 }
 
 func (b *comparisonQueryBuilder) InUint8(key string, values ...uint8) *Builder {
+	b.parent.checkIn(key, len(values))
 	e := bson.E{Key: InOp, Value: values}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -0,0 +1,100 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import "github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
+
+// If calls fn with the builder if cond is true, so that the optional conditions don't break the chain,
+// such as If(req.OnlyAdult, func(b *Builder) { b.Gte("age", 18) })
+func (b *Builder) If(cond bool, fn func(b *Builder)) *Builder {
+	if cond && fn != nil {
+		fn(b)
+	}
+	return b
+}
+
+// WhenNotZero calls fn with the builder if the value is not the zero value of its type
+func (b *Builder) WhenNotZero(value any, fn func(b *Builder)) *Builder {
+	return b.If(!utils.IsZero(value), fn)
+}
+
+// WhenNotNil calls fn with the builder if the value is neither nil nor a nil pointer, map or slice
+func (b *Builder) WhenNotNil(value any, fn func(b *Builder)) *Builder {
+	return b.If(!utils.IsNil(value), fn)
+}
+
+// EqIfNotZero is the same as Eq if the value is not the zero value of its type, otherwise it does nothing
+func (b *Builder) EqIfNotZero(key string, value any) *Builder {
+	if !utils.IsZero(value) {
+		b.Eq(key, value)
+	}
+	return b
+}
+
+// NeIfNotZero is the same as Ne if the value is not the zero value of its type, otherwise it does nothing
+func (b *Builder) NeIfNotZero(key string, value any) *Builder {
+	if !utils.IsZero(value) {
+		b.Ne(key, value)
+	}
+	return b
+}
+
+// GtIfNotZero is the same as Gt if the value is not the zero value of its type, otherwise it does nothing
+func (b *Builder) GtIfNotZero(key string, value any) *Builder {
+	if !utils.IsZero(value) {
+		b.Gt(key, value)
+	}
+	return b
+}
+
+// GteIfNotZero is the same as Gte if the value is not the zero value of its type, otherwise it does nothing
+func (b *Builder) GteIfNotZero(key string, value any) *Builder {
+	if !utils.IsZero(value) {
+		b.Gte(key, value)
+	}
+	return b
+}
+
+// LtIfNotZero is the same as Lt if the value is not the zero value of its type, otherwise it does nothing
+func (b *Builder) LtIfNotZero(key string, value any) *Builder {
+	if !utils.IsZero(value) {
+		b.Lt(key, value)
+	}
+	return b
+}
+
+// LteIfNotZero is the same as Lte if the value is not the zero value of its type, otherwise it does nothing
+func (b *Builder) LteIfNotZero(key string, value any) *Builder {
+	if !utils.IsZero(value) {
+		b.Lte(key, value)
+	}
+	return b
+}
+
+// InIfNotEmpty is the same as In if there is any value, otherwise it does nothing
+func (b *Builder) InIfNotEmpty(key string, values ...any) *Builder {
+	if len(values) > 0 {
+		b.In(key, values...)
+	}
+	return b
+}
+
+// NinIfNotEmpty is the same as Nin if there is any value, otherwise it does nothing
+func (b *Builder) NinIfNotEmpty(key string, values ...any) *Builder {
+	if len(values) > 0 {
+		b.Nin(key, values...)
+	}
+	return b
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestBuilder_If(t *testing.T) {
+	type request struct {
+		Name     string
+		MinAge   int
+		MaxAge   int
+		Since    time.Time
+		Verified *bool
+		Tags     []any
+	}
+	verified := false
+	testCases := []struct {
+		name string
+		req  request
+		want bson.D
+	}{
+		{
+			name: "empty request",
+			want: bson.D{{Key: "status", Value: bson.D{{Key: EqOp, Value: "active"}}}},
+		},
+		{
+			name: "all the fields",
+			req:  request{Name: "cmy", MinAge: 18, MaxAge: 30, Since: time.Unix(0, 0), Verified: &verified, Tags: []any{"go"}},
+			want: bson.D{
+				{Key: "status", Value: bson.D{{Key: EqOp, Value: "active"}}},
+				{Key: "name", Value: bson.D{{Key: EqOp, Value: "cmy"}}},
+				{Key: "age", Value: bson.D{{Key: GteOp, Value: 18}, {Key: LteOp, Value: 30}}},
+				{Key: "created_at", Value: bson.D{{Key: GtOp, Value: time.Unix(0, 0)}}},
+				{Key: "verified", Value: bson.D{{Key: EqOp, Value: false}}},
+				{Key: "tags", Value: bson.D{{Key: InOp, Value: []any{"go"}}}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewBuilder().
+				Eq("status", "active").
+				EqIfNotZero("name", tc.req.Name).
+				GteIfNotZero("age", tc.req.MinAge).
+				LteIfNotZero("age", tc.req.MaxAge).
+				WhenNotZero(tc.req.Since, func(b *Builder) { b.Gt("created_at", tc.req.Since) }).
+				WhenNotNil(tc.req.Verified, func(b *Builder) { b.Eq("verified", *tc.req.Verified) }).
+				InIfNotEmpty("tags", tc.req.Tags...).
+				Build()
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBuilder_IfNotZero(t *testing.T) {
+	assert.Equal(t, bson.D{}, NewBuilder().If(false, func(b *Builder) { b.Eq("name", "cmy") }).If(true, nil).
+		NeIfNotZero("name", "").GtIfNotZero("age", 0).LtIfNotZero("age", nil).NinIfNotEmpty("tags").Build())
+	assert.Equal(t, bson.D{
+		{Key: "name", Value: bson.D{{Key: NeOp, Value: "cmy"}}},
+		{Key: "age", Value: bson.D{{Key: GtOp, Value: 1}, {Key: LtOp, Value: 9}}},
+		{Key: "tags", Value: bson.D{{Key: NinOp, Value: []any{"go"}}}},
+	}, NewBuilder().NeIfNotZero("name", "cmy").GtIfNotZero("age", 1).LtIfNotZero("age", 9).NinIfNotEmpty("tags", "go").Build())
+}
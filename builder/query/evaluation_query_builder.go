@@ -34,7 +34,8 @@ func (b *evaluationQueryBuilder) JsonSchema(value any) *Builder {
 }
 
 func (b *evaluationQueryBuilder) Mod(key string, divisor any, remainder int) *Builder {
-	if utils.IsNumeric(divisor) {
+	b.parent.checkMod(key, divisor)
+	if divisor != nil && utils.IsNumeric(divisor) {
 		e := bson.E{Key: ModOp, Value: bson.A{divisor, remainder}}
 		if !b.parent.tryMergeValue(key, e) {
 			b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -44,6 +45,7 @@ func (b *evaluationQueryBuilder) Mod(key string, divisor any, remainder int) *Bu
 }
 
 func (b *evaluationQueryBuilder) Regex(key, value string) *Builder {
+	b.parent.checkRegex(key, value)
 	e := bson.E{Key: RegexOp, Value: value}
 	if !b.parent.tryMergeValue(key, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
@@ -52,6 +54,8 @@ func (b *evaluationQueryBuilder) Regex(key, value string) *Builder {
 }
 
 func (b *evaluationQueryBuilder) RegexOptions(key, value, options string) *Builder {
+	b.parent.checkRegex(key, value)
+	b.parent.checkRegexOptions(key, options)
 	if !b.parent.tryMergeValue(key, bson.E{Key: RegexOp, Value: value}, bson.E{Key: OptionsOp, Value: options}) {
 		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{bson.E{Key: RegexOp, Value: value}, bson.E{Key: OptionsOp, Value: options}}})
 	}
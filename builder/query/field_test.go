@@ -0,0 +1,36 @@
+// Copyright 2023 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestField(t *testing.T) {
+	type user struct {
+		Name string `bson:"name"`
+	}
+
+	key, err := Field[user]("Name")
+	assert.NoError(t, err)
+	assert.Equal(t, "name", key)
+
+	_, err = Field[user]("Age")
+	assert.ErrorIs(t, err, field.ErrUnknownField)
+}
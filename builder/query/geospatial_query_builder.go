@@ -0,0 +1,92 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"github.com/chenmingyong0423/go-mongox/v2/geo"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type geospatialQueryBuilder struct {
+	parent *Builder
+}
+
+// Near appends an element with '$near' key and the point with the distances to the builder's data slice.
+func (b *geospatialQueryBuilder) Near(key string, point geo.Point, opt *NearOptions) *Builder {
+	b.parent.checkGeometry(NearOp, key, point)
+	b.parent.checkNearOptions(NearOp, key, opt)
+	return b.appendGeo(key, bson.E{Key: NearOp, Value: nearValue(point, opt)})
+}
+
+// NearSphere appends an element with '$nearSphere' key and the point with the distances to the builder's data slice.
+func (b *geospatialQueryBuilder) NearSphere(key string, point geo.Point, opt *NearOptions) *Builder {
+	b.parent.checkGeometry(NearSphereOp, key, point)
+	b.parent.checkNearOptions(NearSphereOp, key, opt)
+	return b.appendGeo(key, bson.E{Key: NearSphereOp, Value: nearValue(point, opt)})
+}
+
+// GeoWithin appends an element with '$geoWithin' key and the polygon or multi polygon to the builder's data slice.
+func (b *geospatialQueryBuilder) GeoWithin(key string, area geo.Area) *Builder {
+	b.parent.checkGeometry(GeoWithinOp, key, area)
+	return b.appendGeo(key, bson.E{Key: GeoWithinOp, Value: bson.D{{Key: GeometryOp, Value: area}}})
+}
+
+// GeoWithinBox appends an element with '$geoWithin' key and the '$box' of the legacy coordinate pairs to the builder's data slice.
+func (b *geospatialQueryBuilder) GeoWithinBox(key string, bottomLeft, topRight geo.Position) *Builder {
+	return b.appendGeo(key, bson.E{Key: GeoWithinOp, Value: bson.D{{Key: BoxOp, Value: []geo.Position{bottomLeft, topRight}}}})
+}
+
+// GeoWithinCenter appends an element with '$geoWithin' key and the '$center' of the legacy coordinate pairs to the builder's data slice.
+func (b *geospatialQueryBuilder) GeoWithinCenter(key string, center geo.Position, radius float64) *Builder {
+	if radius < 0 {
+		b.parent.addErr("the radius of %s on %q must not be negative, got %v", CenterOp, key, radius)
+	}
+	return b.appendGeo(key, bson.E{Key: GeoWithinOp, Value: bson.D{{Key: CenterOp, Value: bson.A{center, radius}}}})
+}
+
+// GeoWithinPolygon appends an element with '$geoWithin' key and the '$polygon' of the legacy coordinate pairs to the builder's data slice.
+func (b *geospatialQueryBuilder) GeoWithinPolygon(key string, points ...geo.Position) *Builder {
+	if len(points) < 3 {
+		b.parent.addErr("the %s on %q needs at least 3 points, got %d", PolygonOp, key, len(points))
+	}
+	return b.appendGeo(key, bson.E{Key: GeoWithinOp, Value: bson.D{{Key: PolygonOp, Value: points}}})
+}
+
+// GeoIntersects appends an element with '$geoIntersects' key and the geometry to the builder's data slice.
+func (b *geospatialQueryBuilder) GeoIntersects(key string, geometry geo.Geometry) *Builder {
+	b.parent.checkGeometry(GeoIntersectsOp, key, geometry)
+	return b.appendGeo(key, bson.E{Key: GeoIntersectsOp, Value: bson.D{{Key: GeometryOp, Value: geometry}}})
+}
+
+func (b *geospatialQueryBuilder) appendGeo(key string, e bson.E) *Builder {
+	if !b.parent.tryMergeValue(key, e) {
+		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
+	}
+	return b.parent
+}
+
+func nearValue(point geo.Point, opt *NearOptions) bson.D {
+	d := bson.D{{Key: GeometryOp, Value: point}}
+	if opt != nil {
+		if opt.MaxDistance != 0 {
+			d = append(d, bson.E{Key: MaxDistanceOp, Value: opt.MaxDistance})
+		}
+		if opt.MinDistance != 0 {
+			d = append(d, bson.E{Key: MinDistanceOp, Value: opt.MinDistance})
+		}
+	}
+	return d
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/chenmingyong0423/go-mongox/v2/geo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var (
+	shenzhen = geo.NewPoint(113.93, 22.53)
+	square   = geo.NewPolygon([]geo.Position{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}})
+)
+
+func Test_geospatialQueryBuilder(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *Builder
+		want    bson.D
+	}{
+		{
+			name:    "near",
+			builder: NewBuilder().Near("location", shenzhen, &NearOptions{MaxDistance: 1000}),
+			want: bson.D{{Key: "location", Value: bson.D{{Key: NearOp, Value: bson.D{
+				{Key: GeometryOp, Value: shenzhen},
+				{Key: MaxDistanceOp, Value: float64(1000)},
+			}}}}},
+		},
+		{
+			name:    "near sphere without options",
+			builder: NewBuilder().NearSphere("location", shenzhen, nil),
+			want:    bson.D{{Key: "location", Value: bson.D{{Key: NearSphereOp, Value: bson.D{{Key: GeometryOp, Value: shenzhen}}}}}},
+		},
+		{
+			name:    "geo within",
+			builder: NewBuilder().GeoWithin("location", square),
+			want:    bson.D{{Key: "location", Value: bson.D{{Key: GeoWithinOp, Value: bson.D{{Key: GeometryOp, Value: square}}}}}},
+		},
+		{
+			name:    "geo within box",
+			builder: NewBuilder().GeoWithinBox("location", geo.Position{0, 0}, geo.Position{1, 1}),
+			want:    bson.D{{Key: "location", Value: bson.D{{Key: GeoWithinOp, Value: bson.D{{Key: BoxOp, Value: []geo.Position{{0, 0}, {1, 1}}}}}}}},
+		},
+		{
+			name:    "geo within center",
+			builder: NewBuilder().GeoWithinCenter("location", geo.Position{0, 0}, 10),
+			want:    bson.D{{Key: "location", Value: bson.D{{Key: GeoWithinOp, Value: bson.D{{Key: CenterOp, Value: bson.A{geo.Position{0, 0}, float64(10)}}}}}}},
+		},
+		{
+			name:    "geo within polygon",
+			builder: NewBuilder().GeoWithinPolygon("location", geo.Position{0, 0}, geo.Position{3, 6}, geo.Position{6, 0}),
+			want:    bson.D{{Key: "location", Value: bson.D{{Key: GeoWithinOp, Value: bson.D{{Key: PolygonOp, Value: []geo.Position{{0, 0}, {3, 6}, {6, 0}}}}}}}},
+		},
+		{
+			name:    "geo intersects merged with exists",
+			builder: NewBuilder().Exists("location", true).GeoIntersects("location", shenzhen),
+			want: bson.D{{Key: "location", Value: bson.D{
+				{Key: ExistsOp, Value: true},
+				{Key: GeoIntersectsOp, Value: bson.D{{Key: GeometryOp, Value: shenzhen}}},
+			}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.builder.BuildE()
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func Test_geospatialQueryBuilder_BuildE(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *Builder
+	}{
+		{name: "invalid point", builder: NewBuilder().Near("location", geo.NewPoint(200, 0), nil)},
+		{name: "negative distance", builder: NewBuilder().NearSphere("location", shenzhen, &NearOptions{MinDistance: -1})},
+		{name: "min distance greater than max distance", builder: NewBuilder().Near("location", shenzhen, &NearOptions{MinDistance: 10, MaxDistance: 5})},
+		{name: "open polygon", builder: NewBuilder().GeoWithin("location", geo.NewPolygon([]geo.Position{{0, 0}, {1, 0}, {1, 1}, {0, 1}}))},
+		{name: "nil geometry", builder: NewBuilder().GeoIntersects("location", nil)},
+		{name: "negative radius", builder: NewBuilder().GeoWithinCenter("location", geo.Position{0, 0}, -1)},
+		{name: "too few points", builder: NewBuilder().GeoWithinPolygon("location", geo.Position{0, 0}, geo.Position{1, 1})},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.builder.BuildE()
+			assert.ErrorIs(t, err, ErrInvalidArgument)
+		})
+	}
+}
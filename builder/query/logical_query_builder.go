@@ -47,3 +47,58 @@ func (b *logicalQueryBuilder) Or(conditions ...any) *Builder {
 	b.parent.data = append(b.parent.data, bson.E{Key: OrOp, Value: conditions})
 	return b.parent
 }
+
+// AndFn appends an element with '$and' key and the conditions built by each of fns to the builder's data slice,
+// the conditions are appended to the existing '$and' of the builder if there is one.
+func (b *logicalQueryBuilder) AndFn(fns ...func(b *Builder)) *Builder {
+	return b.appendLogical(AndOp, fns)
+}
+
+// NorFn appends an element with '$nor' key and the conditions built by each of fns to the builder's data slice,
+// the conditions are appended to the existing '$nor' of the builder if there is one.
+func (b *logicalQueryBuilder) NorFn(fns ...func(b *Builder)) *Builder {
+	return b.appendLogical(NorOp, fns)
+}
+
+// OrFn appends an element with '$or' key and the conditions built by each of fns to the builder's data slice,
+// such as OrFn(func(b *Builder) { b.Eq("name", "cmy") }, func(b *Builder) { b.Gt("age", 18) }).
+// The errors of the conditions are reported by BuildE, so is a second '$or' of the builder,
+// which should be wrapped in AndFn, because merging the conditions would loosen the query.
+func (b *logicalQueryBuilder) OrFn(fns ...func(b *Builder)) *Builder {
+	return b.appendLogical(OrOp, fns)
+}
+
+// NotFn appends the '$not' of the operators built by fn to the value of the key,
+// the operators are built on the key or an empty key, such as NotFn("age", func(b *Builder) { b.Gt("", 18) }),
+// and the conditions on other keys are reported by BuildE.
+func (b *logicalQueryBuilder) NotFn(key string, fn func(b *Builder)) *Builder {
+	e := bson.E{Key: NotOp, Value: b.parent.buildOperators(NotOp, key, fn)}
+	if !b.parent.tryMergeValue(key, e) {
+		b.parent.data = append(b.parent.data, bson.E{Key: key, Value: bson.D{e}})
+	}
+	return b.parent
+}
+
+func (b *logicalQueryBuilder) appendLogical(op string, fns []func(b *Builder)) *Builder {
+	if len(fns) == 0 {
+		b.parent.addErr("the conditions of %s must not be empty", op)
+	}
+	conditions := make([]any, 0, len(fns))
+	for _, fn := range fns {
+		conditions = append(conditions, b.parent.build(fn))
+	}
+	for idx, datum := range b.parent.data {
+		if datum.Key != op {
+			continue
+		}
+		existing, ok := datum.Value.([]any)
+		if op == OrOp || !ok {
+			b.parent.addErr("the builder already has %s, the conditions must be built in one call", op)
+			return b.parent
+		}
+		b.parent.data[idx].Value = append(existing[:len(existing):len(existing)], conditions...)
+		return b.parent
+	}
+	b.parent.data = append(b.parent.data, bson.E{Key: op, Value: conditions})
+	return b.parent
+}
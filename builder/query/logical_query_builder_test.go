@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
@@ -36,3 +37,92 @@ func Test_logicalQueryBuilder_Nor(t *testing.T) {
 func Test_logicalQueryBuilder_Or(t *testing.T) {
 	assert.Equal(t, bson.D{{Key: "$or", Value: []any{bson.D{{Key: "name", Value: "cmy"}}}}}, NewBuilder().Or(bson.D{{Key: "name", Value: "cmy"}}).Build())
 }
+
+func Test_logicalQueryBuilder_OrFn(t *testing.T) {
+	got, err := NewBuilder().Eq("status", "active").OrFn(
+		func(b *Builder) { b.Eq("name", "cmy") },
+		func(b *Builder) { b.Gt("age", 18).Lt("age", 30) },
+	).BuildE()
+	require.NoError(t, err)
+	assert.Equal(t, bson.D{
+		{Key: "status", Value: bson.D{{Key: EqOp, Value: "active"}}},
+		{Key: OrOp, Value: []any{
+			bson.D{{Key: "name", Value: bson.D{{Key: EqOp, Value: "cmy"}}}},
+			bson.D{{Key: "age", Value: bson.D{{Key: GtOp, Value: 18}, {Key: LtOp, Value: 30}}}},
+		}},
+	}, got)
+
+	_, err = NewBuilder().OrFn().BuildE()
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	// a second $or would loosen the query if its conditions were merged
+	_, err = NewBuilder().OrFn(func(b *Builder) { b.Eq("name", "cmy") }).OrFn(func(b *Builder) { b.Gt("age", 18) }).BuildE()
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	// the errors of the nested builders are reported by the parent
+	_, err = NewBuilder().OrFn(func(b *Builder) {
+		b.AndFn(func(b *Builder) { b.In("name") })
+	}).BuildE()
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func Test_logicalQueryBuilder_AndFn(t *testing.T) {
+	assert.Equal(t, bson.D{{Key: AndOp, Value: []any{
+		bson.D{{Key: "name", Value: bson.D{{Key: EqOp, Value: "cmy"}}}},
+		bson.D{},
+	}}}, NewBuilder().AndFn(func(b *Builder) { b.Eq("name", "cmy") }, nil).Build())
+
+	// the conditions of a second call are appended to the existing $and
+	assert.Equal(t, bson.D{{Key: AndOp, Value: []any{
+		bson.D{{Key: "name", Value: bson.D{{Key: EqOp, Value: "cmy"}}}},
+		bson.D{{Key: "age", Value: bson.D{{Key: GtOp, Value: 18}}}},
+	}}}, NewBuilder().AndFn(func(b *Builder) { b.Eq("name", "cmy") }).AndFn(func(b *Builder) { b.Gt("age", 18) }).Build())
+
+	// the $and set by KeyValue can't be merged
+	_, err := NewBuilder().KeyValue(AndOp, bson.A{bson.D{}}).AndFn(func(b *Builder) { b.Gt("age", 18) }).BuildE()
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func Test_logicalQueryBuilder_NorFn(t *testing.T) {
+	assert.Equal(t, bson.D{{Key: NorOp, Value: []any{
+		bson.D{{Key: "age", Value: bson.D{{Key: LtOp, Value: 18}}}},
+	}}}, NewBuilder().NorFn(func(b *Builder) { b.Lt("age", 18) }).Build())
+
+	assert.Equal(t, bson.D{{Key: NorOp, Value: []any{
+		bson.D{{Key: "age", Value: bson.D{{Key: LtOp, Value: 18}}}},
+		bson.D{{Key: "name", Value: bson.D{{Key: EqOp, Value: "cmy"}}}},
+	}}}, NewBuilder().NorFn(func(b *Builder) { b.Lt("age", 18) }).NorFn(func(b *Builder) { b.Eq("name", "cmy") }).Build())
+}
+
+func Test_logicalQueryBuilder_NotFn(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *Builder
+		want    bson.D
+		wantErr error
+	}{
+		{
+			name:    "operators on an empty key",
+			builder: NewBuilder().NotFn("age", func(b *Builder) { b.Gt("", 18).Lt("", 30) }),
+			want:    bson.D{{Key: "age", Value: bson.D{{Key: NotOp, Value: bson.D{{Key: GtOp, Value: 18}, {Key: LtOp, Value: 30}}}}}},
+		},
+		{
+			name:    "operators on the key merged with the other conditions",
+			builder: NewBuilder().Exists("name", true).NotFn("name", func(b *Builder) { b.Regex("name", "^c") }),
+			want:    bson.D{{Key: "name", Value: bson.D{{Key: ExistsOp, Value: true}, {Key: NotOp, Value: bson.D{{Key: RegexOp, Value: "^c"}}}}}},
+		},
+		{
+			name:    "conditions on another key",
+			builder: NewBuilder().NotFn("age", func(b *Builder) { b.Gt("score", 1) }),
+			want:    bson.D{{Key: "age", Value: bson.D{{Key: NotOp, Value: bson.D{}}}}},
+			wantErr: ErrInvalidArgument,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.builder.BuildE()
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
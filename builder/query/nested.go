@@ -0,0 +1,106 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Nested prefixes the keys of the conditions built by fn with "prefix.", including the ones within $and, $or and $nor,
+// such as Nested("address", func(b *Builder) { b.Eq("city", "shenzhen") }) for {"address.city": {$eq: "shenzhen"}}.
+// The prefix can contain array indexes, such as "items.0", the calls can be nested,
+// and an empty key refers to the prefix itself. The conditions which can't be prefixed, such as $expr, are reported by BuildE.
+func (b *Builder) Nested(prefix string, fn func(b *Builder)) *Builder {
+	for _, e := range b.build(fn) {
+		e, ok := prefixElement(prefix, e)
+		if !ok {
+			b.addErr("the condition %s can't be nested in %q", e.Key, prefix)
+			continue
+		}
+		if d, isDoc := e.Value.(bson.D); isDoc && b.tryMergeValue(e.Key, d...) {
+			continue
+		}
+		b.data = append(b.data, e)
+	}
+	return b
+}
+
+func prefixElement(prefix string, e bson.E) (bson.E, bool) {
+	switch {
+	case e.Key == AndOp || e.Key == OrOp || e.Key == NorOp:
+		var conditions []any
+		switch c := e.Value.(type) {
+		case []any:
+			conditions = c
+		case bson.A:
+			conditions = c
+		default:
+			return e, false
+		}
+		prefixed := make([]any, 0, len(conditions))
+		for _, condition := range conditions {
+			c, ok := prefixCondition(prefix, condition)
+			if !ok {
+				return e, false
+			}
+			prefixed = append(prefixed, c)
+		}
+		return bson.E{Key: e.Key, Value: prefixed}, true
+	case strings.HasPrefix(e.Key, "$"):
+		return e, false
+	}
+	return bson.E{Key: joinPath(prefix, e.Key), Value: e.Value}, true
+}
+
+func prefixCondition(prefix string, condition any) (bson.D, bool) {
+	var d bson.D
+	switch c := condition.(type) {
+	case bson.D:
+		d = c
+	case bson.M:
+		keys := make([]string, 0, len(c))
+		for k := range c {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			d = append(d, bson.E{Key: k, Value: c[k]})
+		}
+	default:
+		return nil, false
+	}
+	prefixed := make(bson.D, 0, len(d))
+	for _, e := range d {
+		e, ok := prefixElement(prefix, e)
+		if !ok {
+			return nil, false
+		}
+		prefixed = append(prefixed, e)
+	}
+	return prefixed, true
+}
+
+func joinPath(prefix, key string) string {
+	switch {
+	case prefix == "":
+		return key
+	case key == "":
+		return prefix
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestBuilder_Nested(t *testing.T) {
+	testCases := []struct {
+		name    string
+		fn      func(b *Builder) *Builder
+		want    bson.D
+		wantErr error
+	}{
+		{
+			name: "prefix",
+			fn: func(b *Builder) *Builder {
+				return b.Nested("address", func(b *Builder) { b.Eq("city", "shenzhen").Exists("street", true) })
+			},
+			want: bson.D{
+				{Key: "address.city", Value: bson.D{{Key: EqOp, Value: "shenzhen"}}},
+				{Key: "address.street", Value: bson.D{{Key: ExistsOp, Value: true}}},
+			},
+		},
+		{
+			name: "nested calls and array indexes",
+			fn: func(b *Builder) *Builder {
+				return b.Nested("orders", func(b *Builder) {
+					b.Nested("items.0", func(b *Builder) { b.Gt("price", 10) })
+				})
+			},
+			want: bson.D{{Key: "orders.items.0.price", Value: bson.D{{Key: GtOp, Value: 10}}}},
+		},
+		{
+			name: "empty key refers to the prefix",
+			fn: func(b *Builder) *Builder {
+				return b.Gte("address", 1).Nested("address", func(b *Builder) { b.Lte("", 2) })
+			},
+			want: bson.D{{Key: "address", Value: bson.D{{Key: GteOp, Value: 1}, {Key: LteOp, Value: 2}}}},
+		},
+		{
+			name: "logical operators",
+			fn: func(b *Builder) *Builder {
+				return b.Nested("address", func(b *Builder) {
+					b.Or(bson.D{{Key: "city", Value: "shenzhen"}}, bson.M{"zip": "518000"})
+				})
+			},
+			want: bson.D{{Key: OrOp, Value: []any{
+				bson.D{{Key: "address.city", Value: "shenzhen"}},
+				bson.D{{Key: "address.zip", Value: "518000"}},
+			}}},
+		},
+		{
+			name: "expr can't be nested",
+			fn: func(b *Builder) *Builder {
+				return b.Nested("address", func(b *Builder) { b.Expr(bson.D{{Key: EqOp, Value: bson.A{"$a", "$b"}}}) })
+			},
+			want:    bson.D{},
+			wantErr: ErrInvalidArgument,
+		},
+		{
+			name: "errors of the closure",
+			fn: func(b *Builder) *Builder {
+				return b.Nested("address", func(b *Builder) { b.Size("tags", -1) })
+			},
+			want:    bson.D{{Key: "address.tags", Value: bson.D{{Key: SizeOp, Value: -1}}}},
+			wantErr: ErrInvalidArgument,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.fn(NewBuilder()).BuildE()
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
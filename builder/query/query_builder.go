@@ -15,6 +15,7 @@
 package query
 
 import (
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
@@ -29,6 +30,8 @@ func NewBuilder() *Builder {
 	query.arrayQueryBuilder = arrayQueryBuilder{parent: query}
 	query.evaluationQueryBuilder = evaluationQueryBuilder{parent: query}
 	query.projectionQueryBuilder = projectionQueryBuilder{parent: query}
+	query.geospatialQueryBuilder = geospatialQueryBuilder{parent: query}
+	query.bitwiseQueryBuilder = bitwiseQueryBuilder{parent: query}
 	return query
 }
 
@@ -40,6 +43,8 @@ type Builder struct {
 	arrayQueryBuilder
 	evaluationQueryBuilder
 	projectionQueryBuilder
+	geospatialQueryBuilder
+	bitwiseQueryBuilder
 
 	err []error
 }
@@ -48,6 +53,13 @@ func (b *Builder) Build() bson.D {
 	return b.data
 }
 
+// BuildE is the same as Build, besides it reports the invalid arguments passed to the builder,
+// such as an empty $in, a negative $size or a $regex pattern which doesn't compile.
+// The errors wrap ErrInvalidArgument.
+func (b *Builder) BuildE() (bson.D, error) {
+	return b.data, utils.JoinErrors(b.err...)
+}
+
 // Id appends an element with '_id' key and given value to the builder's data slice.
 func (b *Builder) Id(v any) *Builder {
 	b.data = append(b.data, bson.E{Key: IdOp, Value: v})
@@ -60,6 +72,31 @@ func (b *Builder) KeyValue(key string, value any) *Builder {
 	return b
 }
 
+// build builds the conditions of fn with a new builder, whose errors are reported by the BuildE of b
+func (b *Builder) build(fn func(b *Builder)) bson.D {
+	sub := NewBuilder()
+	if fn != nil {
+		fn(sub)
+	}
+	b.err = append(b.err, sub.err...)
+	return sub.data
+}
+
+// buildOperators builds the conditions of fn on the key, such as b.Gt(key, 1) or b.Gt("", 1),
+// and returns their operators as a document like {$gt: 1}
+func (b *Builder) buildOperators(op, key string, fn func(b *Builder)) bson.D {
+	operators := bson.D{}
+	for _, e := range b.build(fn) {
+		d, ok := e.Value.(bson.D)
+		if (e.Key != key && e.Key != "") || !ok {
+			b.addErr("the conditions of %s on %q must be operators on the same key, got %q", op, key, e.Key)
+			continue
+		}
+		operators = append(operators, d...)
+	}
+	return operators
+}
+
 // tryMergeValue attempts to merge the provided bson.E elements into an existing bson.D element
 // in the builder's data slice, identified by the specified key.
 func (b *Builder) tryMergeValue(key string, e ...bson.E) bool {
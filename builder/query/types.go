@@ -17,12 +17,21 @@ package query
 const (
 	AllOp                = "$all"
 	AndOp                = "$and"
+	BitsAllClearOp       = "$bitsAllClear"
+	BitsAllSetOp         = "$bitsAllSet"
+	BitsAnyClearOp       = "$bitsAnyClear"
+	BitsAnySetOp         = "$bitsAnySet"
+	BoxOp                = "$box"
 	CaseSensitiveOp      = "$caseSensitive"
+	CenterOp             = "$center"
 	DiacriticSensitiveOp = "$diacriticSensitive"
 	ElemMatchOp          = "$elemMatch"
 	EqOp                 = "$eq"
 	ExistsOp             = "$exists"
 	ExprOp               = "$expr"
+	GeoIntersectsOp      = "$geoIntersects"
+	GeoWithinOp          = "$geoWithin"
+	GeometryOp           = "$geometry"
 	GtOp                 = "$gt"
 	GteOp                = "$gte"
 	IdOp                 = "_id"
@@ -31,13 +40,18 @@ const (
 	LanguageOp           = "$language"
 	LtOp                 = "$lt"
 	LteOp                = "$lte"
+	MaxDistanceOp        = "$maxDistance"
+	MinDistanceOp        = "$minDistance"
 	ModOp                = "$mod"
 	NeOp                 = "$ne"
+	NearOp               = "$near"
+	NearSphereOp         = "$nearSphere"
 	NinOp                = "$nin"
 	NorOp                = "$nor"
 	NotOp                = "$not"
 	OptionsOp            = "$options"
 	OrOp                 = "$or"
+	PolygonOp            = "$polygon"
 	RegexOp              = "$regex"
 	SearchOp             = "$search"
 	SizeOp               = "$size"
@@ -52,3 +66,10 @@ type TextOptions struct {
 	CaseSensitive      bool
 	DiacriticSensitive bool
 }
+
+// NearOptions are the distances in meters of $near and $nearSphere,
+// the zero values are not used as query conditions
+type NearOptions struct {
+	MinDistance float64
+	MaxDistance float64
+}
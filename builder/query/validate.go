@@ -0,0 +1,147 @@
+// Copyright 2023 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/chenmingyong0423/go-mongox/v2/geo"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ErrInvalidArgument is wrapped by the errors reported by BuildE
+var ErrInvalidArgument = utils.ErrInvalidArgument
+
+// regexOptions are the options supported by $regex
+const regexOptions = "imxsu"
+
+func (b *Builder) addErr(format string, args ...any) {
+	b.err = append(b.err, fmt.Errorf("%w: "+format, append([]any{ErrInvalidArgument}, args...)...))
+}
+
+func (b *Builder) checkMod(key string, divisor any) {
+	if divisor == nil || !utils.IsNumeric(divisor) {
+		b.addErr("the divisor of %s on %q must be a number, got %T", ModOp, key, divisor)
+		return
+	}
+	if reflect.ValueOf(divisor).IsZero() {
+		b.addErr("the divisor of %s on %q must not be zero", ModOp, key)
+	}
+}
+
+func (b *Builder) checkIn(key string, n int) {
+	if n == 0 {
+		b.addErr("the values of %s on %q must not be empty", InOp, key)
+	}
+}
+
+func (b *Builder) checkSize(key string, size int) {
+	if size < 0 {
+		b.addErr("the size of %s on %q must not be negative, got %d", SizeOp, key, size)
+	}
+}
+
+// checkRegex reports the patterns with syntax errors, the features of PCRE which aren't supported by RE2,
+// such as the lookarounds, are left to the server
+func (b *Builder) checkRegex(key, pattern string) {
+	if _, err := regexp.Compile(pattern); err != nil {
+		var syntaxErr *syntax.Error
+		if errors.As(err, &syntaxErr) {
+			switch syntaxErr.Code {
+			case syntax.ErrInvalidPerlOp, syntax.ErrInvalidEscape, syntax.ErrInvalidNamedCapture, syntax.ErrInvalidRepeatOp, syntax.ErrInvalidRepeatSize, syntax.ErrNestingDepth, syntax.ErrLarge:
+				return
+			}
+		}
+		b.addErr("the pattern of %s on %q doesn't compile: %v", RegexOp, key, err)
+	}
+}
+
+func (b *Builder) checkRegexOptions(key, options string) {
+	for _, option := range options {
+		if !strings.ContainsRune(regexOptions, option) {
+			b.addErr("the option %q of %s on %q isn't supported, the options are %q", option, RegexOp, key, regexOptions)
+		}
+	}
+}
+
+func (b *Builder) checkGeometry(op, key string, geometry geo.Geometry) {
+	if geometry == nil {
+		b.addErr("the geometry of %s on %q must not be nil", op, key)
+		return
+	}
+	if err := geometry.Validate(); err != nil {
+		b.addErr("the geometry of %s on %q is invalid: %v", op, key, err)
+	}
+}
+
+func (b *Builder) checkNearOptions(op, key string, opt *NearOptions) {
+	if opt == nil {
+		return
+	}
+	if opt.MinDistance < 0 || opt.MaxDistance < 0 {
+		b.addErr("the distances of %s on %q must not be negative, got min %v and max %v", op, key, opt.MinDistance, opt.MaxDistance)
+	}
+	if opt.MaxDistance != 0 && opt.MinDistance > opt.MaxDistance {
+		b.addErr("the min distance of %s on %q must not be greater than the max distance, got min %v and max %v", op, key, opt.MinDistance, opt.MaxDistance)
+	}
+}
+
+// checkBitmask reports whether the bitmask is a non-negative integer, a slice of non-negative bit positions or binary data
+func (b *Builder) checkBitmask(op, key string, bitmask any) {
+	switch bitmask.(type) {
+	case bson.Binary, []byte:
+		return
+	case nil:
+		b.addErr("the bitmask of %s on %q must not be nil", op, key)
+		return
+	}
+	v := reflect.ValueOf(bitmask)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Int() < 0 {
+			b.addErr("the bitmask of %s on %q must not be negative, got %d", op, key, v.Int())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			b.addErr("the bitmask of %s on %q overflows int64, got %d", op, key, v.Uint())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			position := v.Index(i)
+			if position.Kind() == reflect.Interface {
+				position = position.Elem()
+			}
+			switch position.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				if position.Int() >= 0 {
+					continue
+				}
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				continue
+			}
+			b.addErr("the bit positions of %s on %q must be non-negative integers, got %v", op, key, bitmask)
+			return
+		}
+	default:
+		b.addErr("the bitmask of %s on %q must be an integer, a slice of bit positions or binary data, got %T", op, key, bitmask)
+	}
+}
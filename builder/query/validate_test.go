@@ -0,0 +1,95 @@
+// Copyright 2023 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestBuilder_BuildE(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *Builder
+		want    bson.D
+		errs    int
+	}{
+		{
+			name:    "valid",
+			builder: NewBuilder().Mod("age", 2, 0).In("name", "cmy").Size("tags", 0).Regex("name", "^c(?=m)").RegexOptions("name", "y$", "i"),
+			want: bson.D{
+				{Key: "age", Value: bson.D{{Key: ModOp, Value: bson.A{2, 0}}}},
+				{Key: "name", Value: bson.D{{Key: InOp, Value: []any{"cmy"}}, {Key: RegexOp, Value: "^c(?=m)"}, {Key: RegexOp, Value: "y$"}, {Key: OptionsOp, Value: "i"}}},
+				{Key: "tags", Value: bson.D{{Key: SizeOp, Value: 0}}},
+			},
+		},
+		{
+			name:    "non-numeric divisor",
+			builder: NewBuilder().Mod("age", "2", 0),
+			want:    bson.D{},
+			errs:    1,
+		},
+		{
+			name:    "nil divisor",
+			builder: NewBuilder().Mod("age", nil, 0),
+			want:    bson.D{},
+			errs:    1,
+		},
+		{
+			name:    "zero divisor",
+			builder: NewBuilder().Mod("age", 0.0, 0),
+			want:    bson.D{{Key: "age", Value: bson.D{{Key: ModOp, Value: bson.A{0.0, 0}}}}},
+			errs:    1,
+		},
+		{
+			name:    "empty in",
+			builder: NewBuilder().In("name").InInt("age"),
+			want:    bson.D{{Key: "name", Value: bson.D{{Key: InOp, Value: []any(nil)}}}, {Key: "age", Value: bson.D{{Key: InOp, Value: []int(nil)}}}},
+			errs:    2,
+		},
+		{
+			name:    "negative size",
+			builder: NewBuilder().Size("tags", -1),
+			want:    bson.D{{Key: "tags", Value: bson.D{{Key: SizeOp, Value: -1}}}},
+			errs:    1,
+		},
+		{
+			name:    "invalid regex",
+			builder: NewBuilder().Regex("name", "c(my").RegexOptions("nickname", "[a-", "ig"),
+			want: bson.D{
+				{Key: "name", Value: bson.D{{Key: RegexOp, Value: "c(my"}}},
+				{Key: "nickname", Value: bson.D{{Key: RegexOp, Value: "[a-"}, {Key: OptionsOp, Value: "ig"}}},
+			},
+			errs: 3,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.builder.BuildE()
+			assert.Equal(t, tc.want, got)
+			if tc.errs == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidArgument)
+			if tc.errs > 1 {
+				assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), tc.errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package typed provides the field paths with the type of their values,
+// which are generated by mongox-gen from the bson tags of the models,
+// so that a renamed bson tag breaks the build instead of the queries.
+package typed
+
+import (
+	"github.com/chenmingyong0423/go-mongox/v2/builder/query"
+	"github.com/chenmingyong0423/go-mongox/v2/builder/update"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Field is the path of a field in mongo whose values are of type V,
+// its methods build the same bson.D as the functions of the query and update packages with the path as the key
+type Field[V any] struct {
+	path string
+}
+
+func NewField[V any](path string) Field[V] {
+	return Field[V]{path: path}
+}
+
+// Path returns the path of the field, which can be used as the key of the builders
+func (f Field[V]) Path() string {
+	return f.path
+}
+
+func (f Field[V]) String() string {
+	return f.path
+}
+
+// Ref returns the reference of the field in an aggregation expression, such as "$age"
+func (f Field[V]) Ref() string {
+	return "$" + f.path
+}
+
+func (f Field[V]) Eq(value V) bson.D {
+	return query.Eq(f.path, value)
+}
+
+func (f Field[V]) Ne(value V) bson.D {
+	return query.Ne(f.path, value)
+}
+
+func (f Field[V]) Gt(value V) bson.D {
+	return query.Gt(f.path, value)
+}
+
+func (f Field[V]) Gte(value V) bson.D {
+	return query.Gte(f.path, value)
+}
+
+func (f Field[V]) Lt(value V) bson.D {
+	return query.Lt(f.path, value)
+}
+
+func (f Field[V]) Lte(value V) bson.D {
+	return query.Lte(f.path, value)
+}
+
+func (f Field[V]) In(values ...V) bson.D {
+	return query.In(f.path, values...)
+}
+
+func (f Field[V]) NIn(values ...V) bson.D {
+	return query.NIn(f.path, values...)
+}
+
+func (f Field[V]) Exists(exists bool) bson.D {
+	return query.Exists(f.path, exists)
+}
+
+func (f Field[V]) Set(value V) bson.D {
+	return update.Set(f.path, value)
+}
+
+func (f Field[V]) SetOnInsert(value V) bson.D {
+	return update.SetOnInsert(f.path, value)
+}
+
+func (f Field[V]) Unset() bson.D {
+	return update.Unset(f.path)
+}
+
+func (f Field[V]) Inc(value V) bson.D {
+	return update.Inc(f.path, value)
+}
+
+func (f Field[V]) Min(value V) bson.D {
+	return update.Min(f.path, value)
+}
+
+func (f Field[V]) Max(value V) bson.D {
+	return update.Max(f.path, value)
+}
+
+// Asc returns the ascending sort by the field
+func (f Field[V]) Asc() bson.E {
+	return bson.E{Key: f.path, Value: 1}
+}
+
+// Desc returns the descending sort by the field
+func (f Field[V]) Desc() bson.E {
+	return bson.E{Key: f.path, Value: -1}
+}
+
+// Slice is the path of an array field whose elements are of type E
+type Slice[E any] struct {
+	Field[[]E]
+}
+
+func NewSlice[E any](path string) Slice[E] {
+	return Slice[E]{Field: NewField[[]E](path)}
+}
+
+// All matches the arrays containing all the values
+func (s Slice[E]) All(values ...E) bson.D {
+	return bson.D{bson.E{Key: s.path, Value: query.All(values...)}}
+}
+
+// Contains matches the arrays containing the value
+func (s Slice[E]) Contains(value E) bson.D {
+	return query.Eq(s.path, value)
+}
+
+// ContainsAny matches the arrays containing any of the values
+func (s Slice[E]) ContainsAny(values ...E) bson.D {
+	return query.In(s.path, values...)
+}
+
+func (s Slice[E]) Size(size int) bson.D {
+	return query.Size(s.path, size)
+}
+
+func (s Slice[E]) ElemMatch(cond any) bson.D {
+	return query.ElemMatch(s.path, cond)
+}
+
+func (s Slice[E]) Push(value E) bson.D {
+	return update.Push(s.path, value)
+}
+
+func (s Slice[E]) AddToSet(value E) bson.D {
+	return update.AddToSet(s.path, value)
+}
+
+func (s Slice[E]) Pull(value E) bson.D {
+	return update.Pull(s.path, value)
+}
+
+func (s Slice[E]) PullAll(values ...E) bson.D {
+	return update.PullAll(s.path, values...)
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typed
+
+import (
+	"testing"
+
+	"github.com/chenmingyong0423/go-mongox/v2/builder/query"
+	"github.com/chenmingyong0423/go-mongox/v2/builder/update"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestField(t *testing.T) {
+	age := NewField[int]("age")
+
+	assert.Equal(t, "age", age.Path())
+	assert.Equal(t, "age", age.String())
+	assert.Equal(t, "$age", age.Ref())
+	assert.Equal(t, query.Eq("age", 18), age.Eq(18))
+	assert.Equal(t, query.Ne("age", 18), age.Ne(18))
+	assert.Equal(t, query.Gt("age", 18), age.Gt(18))
+	assert.Equal(t, query.Gte("age", 18), age.Gte(18))
+	assert.Equal(t, query.Lt("age", 18), age.Lt(18))
+	assert.Equal(t, query.Lte("age", 18), age.Lte(18))
+	assert.Equal(t, query.In("age", 18, 19), age.In(18, 19))
+	assert.Equal(t, query.NIn("age", 18, 19), age.NIn(18, 19))
+	assert.Equal(t, query.Exists("age", true), age.Exists(true))
+	assert.Equal(t, update.Set("age", 18), age.Set(18))
+	assert.Equal(t, update.SetOnInsert("age", 18), age.SetOnInsert(18))
+	assert.Equal(t, update.Unset("age"), age.Unset())
+	assert.Equal(t, update.Inc("age", 1), age.Inc(1))
+	assert.Equal(t, update.Min("age", 18), age.Min(18))
+	assert.Equal(t, update.Max("age", 18), age.Max(18))
+	assert.Equal(t, bson.D{age.Asc()}, bson.D{{Key: "age", Value: 1}})
+	assert.Equal(t, bson.D{age.Desc()}, bson.D{{Key: "age", Value: -1}})
+}
+
+func TestSlice(t *testing.T) {
+	tags := NewSlice[string]("tags")
+
+	assert.Equal(t, "tags", tags.Path())
+	assert.Equal(t, query.Eq("tags", []string{"go"}), tags.Eq([]string{"go"}))
+	assert.Equal(t, bson.D{{Key: "tags", Value: query.All("go", "mongo")}}, tags.All("go", "mongo"))
+	assert.Equal(t, query.Eq("tags", "go"), tags.Contains("go"))
+	assert.Equal(t, query.In("tags", "go", "mongo"), tags.ContainsAny("go", "mongo"))
+	assert.Equal(t, query.Size("tags", 2), tags.Size(2))
+	assert.Equal(t, query.ElemMatch("tags", query.Eq("$eq", "go")), tags.ElemMatch(query.Eq("$eq", "go")))
+	assert.Equal(t, update.Push("tags", "go"), tags.Push("go"))
+	assert.Equal(t, update.AddToSet("tags", "go"), tags.AddToSet("go"))
+	assert.Equal(t, update.Pull("tags", "go"), tags.Pull("go"))
+	assert.Equal(t, update.PullAll("tags", "go", "mongo"), tags.PullAll("go", "mongo"))
+}
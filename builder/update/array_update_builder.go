@@ -31,6 +31,7 @@ func (b *arrayUpdateBuilder) AddToSet(key string, value any) *Builder {
 }
 
 func (b *arrayUpdateBuilder) Pop(key string, value any) *Builder {
+	b.parent.checkPop(key, value)
 	e := bson.E{Key: key, Value: value}
 	if !b.parent.tryMergeValue(PopOp, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: PopOp, Value: bson.D{e}})
@@ -0,0 +1,61 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package update
+
+import "github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
+
+// If calls fn with the builder if cond is true, so that the optional updates don't break the chain,
+// such as If(req.Reset, func(b *Builder) { b.Unset("token") })
+func (b *Builder) If(cond bool, fn func(b *Builder)) *Builder {
+	if cond && fn != nil {
+		fn(b)
+	}
+	return b
+}
+
+// WhenNotZero calls fn with the builder if the value is not the zero value of its type
+func (b *Builder) WhenNotZero(value any, fn func(b *Builder)) *Builder {
+	return b.If(!utils.IsZero(value), fn)
+}
+
+// WhenNotNil calls fn with the builder if the value is neither nil nor a nil pointer, map or slice
+func (b *Builder) WhenNotNil(value any, fn func(b *Builder)) *Builder {
+	return b.If(!utils.IsNil(value), fn)
+}
+
+// SetIfNotZero is the same as Set if the value is not the zero value of its type, otherwise it does nothing
+func (b *Builder) SetIfNotZero(key string, value any) *Builder {
+	if !utils.IsZero(value) {
+		b.Set(key, value)
+	}
+	return b
+}
+
+// SetIfNotNil is the same as Set if the value is not nil, otherwise it does nothing.
+// It suits the optional fields of a partial update, whose pointers are nil if they are not provided.
+func (b *Builder) SetIfNotNil(key string, value any) *Builder {
+	if !utils.IsNil(value) {
+		b.Set(key, value)
+	}
+	return b
+}
+
+// IncIfNotZero is the same as Inc if the value is not zero, otherwise it does nothing
+func (b *Builder) IncIfNotZero(key string, value any) *Builder {
+	if !utils.IsZero(value) {
+		b.Inc(key, value)
+	}
+	return b
+}
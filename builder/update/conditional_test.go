@@ -0,0 +1,71 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestBuilder_If(t *testing.T) {
+	type patch struct {
+		Name  *string
+		Age   int
+		Score int
+		Reset bool
+	}
+	name := "cmy"
+	testCases := []struct {
+		name  string
+		patch patch
+		want  bson.D
+	}{
+		{
+			name: "empty patch",
+			want: bson.D{},
+		},
+		{
+			name:  "all the fields",
+			patch: patch{Name: &name, Age: 18, Score: 2, Reset: true},
+			want: bson.D{
+				{Key: SetOp, Value: bson.D{{Key: "name", Value: &name}, {Key: "age", Value: 18}}},
+				{Key: IncOp, Value: bson.D{{Key: "score", Value: 2}}},
+				{Key: UnsetOp, Value: bson.D{{Key: "token", Value: ""}}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewBuilder().
+				SetIfNotNil("name", tc.patch.Name).
+				SetIfNotZero("age", tc.patch.Age).
+				IncIfNotZero("score", tc.patch.Score).
+				If(tc.patch.Reset, func(b *Builder) { b.Unset("token") }).
+				Build()
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBuilder_When(t *testing.T) {
+	var tags []string
+	assert.Equal(t, bson.D{}, NewBuilder().WhenNotNil(tags, func(b *Builder) { b.Set("tags", tags) }).WhenNotZero(0, func(b *Builder) { b.Set("age", 0) }).Build())
+
+	tags = []string{}
+	assert.Equal(t, bson.D{{Key: SetOp, Value: bson.D{{Key: "tags", Value: tags}, {Key: "age", Value: 1}}}},
+		NewBuilder().WhenNotNil(tags, func(b *Builder) { b.Set("tags", tags) }).WhenNotZero(1, func(b *Builder) { b.Set("age", 1) }).Build())
+}
@@ -0,0 +1,23 @@
+// Copyright 2023 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package update
+
+import "github.com/chenmingyong0423/go-mongox/v2/field"
+
+// Field returns the mongo path of the Go field path of T, such as "Profile.Address.City",
+// which can be used as the key of the builders. field.ErrUnknownField is returned for an unknown field.
+func Field[T any](goPath string) (string, error) {
+	return field.ResolvePath[T](goPath)
+}
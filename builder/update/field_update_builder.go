@@ -33,7 +33,7 @@ func (b *fieldUpdateBuilder) Set(key string, value any) *Builder {
 func (b *fieldUpdateBuilder) SetFields(value any) *Builder {
 	e := bson.E{Key: SetOp, Value: value}
 	if !b.parent.tryMergeValue(SetOp, e) {
-		b.parent.data = append(b.parent.data, e)
+		b.parent.data = append(b.parent.data, b.parent.mergedDocument(e))
 	}
 
 	return b.parent
@@ -59,7 +59,7 @@ func (b *fieldUpdateBuilder) SetOnInsert(key string, value any) *Builder {
 func (b *fieldUpdateBuilder) SetOnInsertAny(value any) *Builder {
 	e := bson.E{Key: SetOnInsertOp, Value: value}
 	if !b.parent.tryMergeValue(SetOnInsertOp, e) {
-		b.parent.data = append(b.parent.data, e)
+		b.parent.data = append(b.parent.data, b.parent.mergedDocument(e))
 	}
 	return b.parent
 }
@@ -73,6 +73,7 @@ func (b *fieldUpdateBuilder) CurrentDate(key string, value any) *Builder {
 }
 
 func (b *fieldUpdateBuilder) Inc(key string, value any) *Builder {
+	b.parent.checkNumeric(IncOp, key, value)
 	e := bson.E{Key: key, Value: value}
 	if !b.parent.tryMergeValue(IncOp, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: IncOp, Value: bson.D{e}})
@@ -97,6 +98,7 @@ func (b *fieldUpdateBuilder) Max(key string, value any) *Builder {
 }
 
 func (b *fieldUpdateBuilder) Mul(key string, value any) *Builder {
+	b.parent.checkNumeric(MulOp, key, value)
 	e := bson.E{Key: key, Value: value}
 	if !b.parent.tryMergeValue(MulOp, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: MulOp, Value: bson.D{e}})
@@ -105,6 +107,7 @@ func (b *fieldUpdateBuilder) Mul(key string, value any) *Builder {
 }
 
 func (b *fieldUpdateBuilder) Rename(key string, value any) *Builder {
+	b.parent.checkRename(key, value)
 	e := bson.E{Key: key, Value: value}
 	if !b.parent.tryMergeValue(RenameOp, e) {
 		b.parent.data = append(b.parent.data, bson.E{Key: RenameOp, Value: bson.D{e}})
@@ -0,0 +1,139 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package update
+
+import (
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Nested prefixes the fields updated by fn with "prefix.", such as Nested("profile", func(b *Builder) { b.Set("name", "chenmingyong") })
+// for {$set: {"profile.name": "chenmingyong"}}. The prefix can contain array indexes and positional operators, such as "items.$[elem]",
+// the calls can be nested, and an empty key refers to the prefix itself.
+// The documents passed to SetFields and SetOnInsertAny are flattened into the prefixed fields at every level,
+// so that the other fields of the sub documents are kept, such as "profile.address.city" for a struct with an address struct.
+// The documents in a bson.M or a map are flattened as well, while a struct inside them and an empty document are set as a whole.
+func (b *Builder) Nested(prefix string, fn func(b *Builder)) *Builder {
+	for _, e := range b.build(fn) {
+		if !strings.HasPrefix(e.Key, "$") {
+			b.data = append(b.data, bson.E{Key: joinPath(prefix, e.Key), Value: e.Value})
+			continue
+		}
+		fields, err := operatorFields(e.Key, e.Value)
+		if err != nil {
+			b.addErr("the value of %s can't be nested in %q: %v", e.Key, prefix, err)
+			continue
+		}
+		prefixed := make(bson.D, 0, len(fields))
+		for _, field := range fields {
+			if newName, ok := field.Value.(string); ok && e.Key == RenameOp {
+				field.Value = joinPath(prefix, newName)
+			}
+			prefixed = append(prefixed, bson.E{Key: joinPath(prefix, field.Key), Value: field.Value})
+		}
+		if !b.tryMergeValue(e.Key, prefixed...) {
+			b.data = append(b.data, bson.E{Key: e.Key, Value: prefixed})
+		}
+	}
+	return b
+}
+
+// operatorFields returns the fields of the operator, the documents merged by SetFields and SetOnInsertAny are expanded and flattened
+func operatorFields(op string, value any) (bson.D, error) {
+	var d bson.D
+	switch v := value.(type) {
+	case bson.D:
+		d = v
+	case bson.M:
+		d = sortedElements(v)
+	case map[string]any:
+		d = sortedElements(v)
+	default:
+		raw, err := bson.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		if err = bson.Unmarshal(raw, &d); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := make(bson.D, 0, len(d))
+	for _, e := range d {
+		if e.Key != op {
+			fields = append(fields, e)
+			continue
+		}
+		expanded, err := operatorFields(op, e.Value)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, flattenFields(expanded)...)
+	}
+	return fields, nil
+}
+
+// flattenFields replaces the fields whose value is a non-empty document with the dotted paths of its fields
+func flattenFields(fields bson.D) bson.D {
+	flattened := make(bson.D, 0, len(fields))
+	for _, e := range fields {
+		sub, ok := subDocument(e.Value)
+		if !ok || len(sub) == 0 {
+			flattened = append(flattened, e)
+			continue
+		}
+		for _, field := range flattenFields(sub) {
+			flattened = append(flattened, bson.E{Key: joinPath(e.Key, field.Key), Value: field.Value})
+		}
+	}
+	return flattened
+}
+
+func subDocument(value any) (bson.D, bool) {
+	switch v := value.(type) {
+	case bson.D:
+		return v, true
+	case bson.M:
+		return sortedElements(v), true
+	case map[string]any:
+		return sortedElements(v), true
+	}
+	return nil, false
+}
+
+func sortedElements(m map[string]any) bson.D {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	d := make(bson.D, 0, len(keys))
+	for _, k := range keys {
+		d = append(d, bson.E{Key: k, Value: m[k]})
+	}
+	return d
+}
+
+func joinPath(prefix, key string) string {
+	switch {
+	case prefix == "":
+		return key
+	case key == "":
+		return prefix
+	}
+	return prefix + "." + key
+}
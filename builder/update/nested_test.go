@@ -0,0 +1,167 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestBuilder_Nested(t *testing.T) {
+	type address struct {
+		City   string `bson:"city"`
+		Street string `bson:"street"`
+	}
+	type profile struct {
+		Name string `bson:"name"`
+		Age  int    `bson:"age"`
+	}
+	type nestedProfile struct {
+		Name    string  `bson:"name"`
+		Address address `bson:"address"`
+	}
+	testCases := []struct {
+		name    string
+		fn      func(b *Builder) *Builder
+		want    bson.D
+		wantErr error
+	}{
+		{
+			name: "prefix",
+			fn: func(b *Builder) *Builder {
+				return b.Nested("profile", func(b *Builder) { b.Set("name", "cmy").Inc("age", 1).Unset("nickname") })
+			},
+			want: bson.D{
+				{Key: SetOp, Value: bson.D{{Key: "profile.name", Value: "cmy"}}},
+				{Key: IncOp, Value: bson.D{{Key: "profile.age", Value: 1}}},
+				{Key: UnsetOp, Value: bson.D{{Key: "profile.nickname", Value: ""}}},
+			},
+		},
+		{
+			name: "merged with the existing operators",
+			fn: func(b *Builder) *Builder {
+				return b.Set("name", "cmy").Nested("profile", func(b *Builder) { b.Set("age", 18) })
+			},
+			want: bson.D{{Key: SetOp, Value: bson.D{{Key: "name", Value: "cmy"}, {Key: "profile.age", Value: 18}}}},
+		},
+		{
+			name: "nested calls and positional paths",
+			fn: func(b *Builder) *Builder {
+				return b.Nested("orders", func(b *Builder) {
+					b.Nested(Filtered("items", "elem"), func(b *Builder) { b.Set("price", 10) })
+					b.Nested("tags.0", func(b *Builder) { b.Set("", "go") })
+				})
+			},
+			want: bson.D{{Key: SetOp, Value: bson.D{
+				{Key: "orders.items.$[elem].price", Value: 10},
+				{Key: "orders.tags.0", Value: "go"},
+			}}},
+		},
+		{
+			name: "rename",
+			fn: func(b *Builder) *Builder {
+				return b.Nested("profile", func(b *Builder) { b.Rename("nickname", "alias") })
+			},
+			want: bson.D{{Key: RenameOp, Value: bson.D{{Key: "profile.nickname", Value: "profile.alias"}}}},
+		},
+		{
+			name: "set fields of a struct",
+			fn: func(b *Builder) *Builder {
+				return b.Nested("profile", func(b *Builder) { b.SetFields(profile{Name: "cmy", Age: 18}) })
+			},
+			want: bson.D{{Key: SetOp, Value: bson.D{{Key: "profile.name", Value: "cmy"}, {Key: "profile.age", Value: int32(18)}}}},
+		},
+		{
+			name: "set fields of a struct in a struct",
+			fn: func(b *Builder) *Builder {
+				return b.Nested("profile", func(b *Builder) {
+					b.SetFields(nestedProfile{Name: "cmy", Address: address{City: "Shenzhen", Street: "Keyuan"}})
+				})
+			},
+			want: bson.D{{Key: SetOp, Value: bson.D{
+				{Key: "profile.name", Value: "cmy"},
+				{Key: "profile.address.city", Value: "Shenzhen"},
+				{Key: "profile.address.street", Value: "Keyuan"},
+			}}},
+		},
+		{
+			name: "set fields of a map in a map and set a document as a whole",
+			fn: func(b *Builder) *Builder {
+				return b.Nested("profile", func(b *Builder) {
+					b.Set("tags", bson.D{{Key: "lang", Value: "go"}}).
+						SetOnInsertAny(bson.M{"address": bson.M{"city": "Shenzhen", "geo": bson.M{"lat": 22.5}}, "extra": bson.M{}})
+				})
+			},
+			want: bson.D{
+				{Key: SetOp, Value: bson.D{{Key: "profile.tags", Value: bson.D{{Key: "lang", Value: "go"}}}}},
+				{Key: SetOnInsertOp, Value: bson.D{
+					{Key: "profile.address.city", Value: "Shenzhen"},
+					{Key: "profile.address.geo.lat", Value: 22.5},
+					{Key: "profile.extra", Value: bson.M{}},
+				}},
+			},
+		},
+		{
+			name: "set fields merged with set",
+			fn: func(b *Builder) *Builder {
+				return b.Nested("profile", func(b *Builder) {
+					b.Set("nickname", "cmy").SetFields(bson.M{"age": 18, "name": "cmy"})
+				})
+			},
+			want: bson.D{{Key: SetOp, Value: bson.D{
+				{Key: "profile.nickname", Value: "cmy"},
+				{Key: "profile.age", Value: 18},
+				{Key: "profile.name", Value: "cmy"},
+			}}},
+		},
+		{
+			name: "set fields of a non document",
+			fn: func(b *Builder) *Builder {
+				return b.Nested("profile", func(b *Builder) { b.SetFields(1) })
+			},
+			want:    bson.D{},
+			wantErr: ErrInvalidArgument,
+		},
+		{
+			name: "nil closure",
+			fn: func(b *Builder) *Builder {
+				return b.Set("name", "cmy").Nested("profile", nil)
+			},
+			want: bson.D{{Key: SetOp, Value: bson.D{{Key: "name", Value: "cmy"}}}},
+		},
+		{
+			name: "errors of the closure",
+			fn: func(b *Builder) *Builder {
+				return b.Nested("profile", func(b *Builder) { b.Inc("age", "1") })
+			},
+			want:    bson.D{{Key: IncOp, Value: bson.D{{Key: "profile.age", Value: "1"}}}},
+			wantErr: ErrInvalidArgument,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.fn(NewBuilder()).BuildE()
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
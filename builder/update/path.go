@@ -0,0 +1,45 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package update
+
+import "strings"
+
+const (
+	PositionalOp    = "$"
+	AllPositionalOp = "$[]"
+)
+
+// Positional returns the path of the first element of the array matched by the filter,
+// such as Positional("items", "qty") for "items.$.qty"
+func Positional(array string, fields ...string) string {
+	return path(array, PositionalOp, fields)
+}
+
+// AllPositional returns the path of all the elements of the array,
+// such as AllPositional("items", "qty") for "items.$[].qty"
+func AllPositional(array string, fields ...string) string {
+	return path(array, AllPositionalOp, fields)
+}
+
+// Filtered returns the path of the elements of the array matched by the array filters of the identifier,
+// such as Filtered("items", "elem", "qty") for "items.$[elem].qty".
+// The paths can be nested, such as Filtered("items", "i", Filtered("sizes", "j", "qty")) for "items.$[i].sizes.$[j].qty".
+func Filtered(array, identifier string, fields ...string) string {
+	return path(array, "$["+identifier+"]", fields)
+}
+
+func path(array, operator string, fields []string) string {
+	return strings.Join(append([]string{array, operator}, fields...), ".")
+}
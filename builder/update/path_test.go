@@ -0,0 +1,38 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPositional(t *testing.T) {
+	assert.Equal(t, "items.$.qty", Positional("items", "qty"))
+	assert.Equal(t, "items.$", Positional("items"))
+	assert.Equal(t, "items.$.size.width", Positional("items", "size", "width"))
+}
+
+func TestAllPositional(t *testing.T) {
+	assert.Equal(t, "items.$[].qty", AllPositional("items", "qty"))
+	assert.Equal(t, "tags.$[]", AllPositional("tags"))
+}
+
+func TestFiltered(t *testing.T) {
+	assert.Equal(t, "items.$[elem].qty", Filtered("items", "elem", "qty"))
+	assert.Equal(t, "tags.$[tag]", Filtered("tags", "tag"))
+	assert.Equal(t, "items.$[i].sizes.$[j].qty", Filtered("items", "i", Filtered("sizes", "j", "qty")))
+}
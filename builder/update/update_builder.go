@@ -15,6 +15,7 @@
 package update
 
 import (
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
@@ -29,6 +30,11 @@ type Builder struct {
 	data bson.D
 	fieldUpdateBuilder
 	arrayUpdateBuilder
+
+	err []error
+	// nested marks the builder of Nested, which keeps the documents of SetFields and SetOnInsertAny in an element of
+	// their operator, so that Nested tells them from the fields updated by key and flattens them
+	nested bool
 }
 
 // KeyValue appends given key-value pair to the builder's data slice.
@@ -41,6 +47,33 @@ func (b *Builder) Build() bson.D {
 	return b.data
 }
 
+// BuildE is the same as Build, besides it reports the invalid arguments passed to the builder,
+// such as a non-numeric $inc, and the paths updated by more than one operator, such as $set and $unset on the same key.
+// The errors wrap ErrInvalidArgument.
+func (b *Builder) BuildE() (bson.D, error) {
+	return b.data, utils.JoinErrors(append(b.err[:len(b.err):len(b.err)], b.conflicts()...)...)
+}
+
+// build runs fn on a new nested builder and returns the updates built by it, the errors are added to b
+func (b *Builder) build(fn func(b *Builder)) bson.D {
+	sub := NewBuilder()
+	sub.nested = true
+	if fn != nil {
+		fn(sub)
+	}
+	b.err = append(b.err, sub.err...)
+	return sub.data
+}
+
+// mergedDocument returns the element of the operator merging the document of e.Value,
+// which is e itself unless the builder is nested
+func (b *Builder) mergedDocument(e bson.E) bson.E {
+	if b.nested {
+		return bson.E{Key: e.Key, Value: bson.D{e}}
+	}
+	return e
+}
+
 // tryMergeValue attempts to merge the provided bson.E elements into an existing bson.D element
 // in the builder's data slice, identified by the specified key.
 func (b *Builder) tryMergeValue(key string, e ...bson.E) bool {
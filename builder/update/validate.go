@@ -0,0 +1,119 @@
+// Copyright 2023 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package update
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ErrInvalidArgument is wrapped by the errors reported by BuildE
+var ErrInvalidArgument = utils.ErrInvalidArgument
+
+func (b *Builder) addErr(format string, args ...any) {
+	b.err = append(b.err, fmt.Errorf("%w: "+format, append([]any{ErrInvalidArgument}, args...)...))
+}
+
+func (b *Builder) checkNumeric(op, key string, value any) {
+	if value == nil || !utils.IsNumeric(value) {
+		b.addErr("the value of %s on %q must be a number, got %T", op, key, value)
+	}
+}
+
+func (b *Builder) checkPop(key string, value any) {
+	if n, ok := toFloat(value); !ok || (n != 1 && n != -1) {
+		b.addErr("the value of %s on %q must be 1 or -1, got %v", PopOp, key, value)
+	}
+}
+
+func toFloat(value any) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+func (b *Builder) checkRename(key string, value any) {
+	newName, ok := value.(string)
+	if !ok || newName == "" || newName == key {
+		b.addErr("the new name of %s on %q must be a non-empty string different from the key, got %v", RenameOp, key, value)
+	}
+}
+
+type operatorPath struct {
+	op, path string
+}
+
+// conflicts reports the paths updated more than once, including a path and its sub paths,
+// whether by the same operator or by different ones, which the server rejects with "Updating the path would create a conflict"
+func (b *Builder) conflicts() []error {
+	paths := make([]operatorPath, 0, len(b.data))
+	for _, e := range b.data {
+		if !strings.HasPrefix(e.Key, "$") {
+			continue
+		}
+		for _, key := range updatedPaths(e) {
+			paths = append(paths, operatorPath{op: e.Key, path: key})
+		}
+	}
+
+	var errs []error
+	for i := range paths {
+		for j := i + 1; j < len(paths); j++ {
+			if overlaps(paths[i].path, paths[j].path) {
+				errs = append(errs, fmt.Errorf("%w: the path %q of %s conflicts with the path %q of %s", ErrInvalidArgument, paths[i].path, paths[i].op, paths[j].path, paths[j].op))
+			}
+		}
+	}
+	return errs
+}
+
+// updatedPaths returns the paths updated by the operator, the new names of $rename are updated as well,
+// a new name equal to its key is reported by checkRename instead
+func updatedPaths(e bson.E) []string {
+	var paths []string
+	switch value := e.Value.(type) {
+	case bson.D:
+		for _, field := range value {
+			paths = append(paths, field.Key)
+			if newName, ok := field.Value.(string); ok && e.Key == RenameOp && newName != field.Key {
+				paths = append(paths, newName)
+			}
+		}
+	case bson.M:
+		for key, v := range value {
+			paths = append(paths, key)
+			if newName, ok := v.(string); ok && e.Key == RenameOp && newName != key {
+				paths = append(paths, newName)
+			}
+		}
+	}
+	return paths
+}
+
+func overlaps(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+".") || strings.HasPrefix(b, a+".")
+}
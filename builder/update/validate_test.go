@@ -0,0 +1,100 @@
+// Copyright 2023 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestBuilder_BuildE(t *testing.T) {
+	testCases := []struct {
+		name    string
+		builder *Builder
+		errs    int
+	}{
+		{
+			name:    "valid",
+			builder: NewBuilder().Set("name", "cmy").Unset("nickname").Inc("age", 1).Mul("score", 1.5).Pop("tags", -1).Rename("old", "new").SetOnInsert("created_at", 1),
+		},
+		{
+			name:    "non-numeric inc and mul",
+			builder: NewBuilder().Inc("age", "1").Mul("score", nil),
+			errs:    2,
+		},
+		{
+			name:    "invalid pop",
+			builder: NewBuilder().Pop("tags", 2),
+			errs:    1,
+		},
+		{
+			name:    "invalid rename",
+			builder: NewBuilder().Rename("name", "name").Rename("age", 1),
+			errs:    2,
+		},
+		{
+			name:    "set and unset the same key",
+			builder: NewBuilder().Set("name", "cmy").Unset("name"),
+			errs:    1,
+		},
+		{
+			name:    "set a sub path of an incremented path",
+			builder: NewBuilder().Set("profile.age", 18).Inc("profile", 1),
+			errs:    1,
+		},
+		{
+			name:    "rename to a set path",
+			builder: NewBuilder().Set("new", 1).Rename("old", "new"),
+			errs:    1,
+		},
+		{
+			name:    "set a path and its sub path",
+			builder: NewBuilder().Set("a", 1).Set("a.b", 2),
+			errs:    1,
+		},
+		{
+			name:    "set the same key twice",
+			builder: NewBuilder().Set("name", "cmy").Set("name", "chenmingyong"),
+			errs:    1,
+		},
+		{
+			name:    "rename to a renamed path",
+			builder: NewBuilder().Rename("a", "b").Rename("b", "c"),
+			errs:    1,
+		},
+		{
+			name:    "set fields and set on insert",
+			builder: NewBuilder().SetFields(bson.M{"name": "cmy"}).SetOnInsert("name", "cmy"),
+			errs:    1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.builder.BuildE()
+			assert.Equal(t, tc.builder.Build(), got)
+			if tc.errs == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidArgument)
+			if tc.errs > 1 {
+				assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), tc.errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkwriter
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/bsonx"
+	"github.com/chenmingyong0423/go-mongox/v2/callback"
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/replace"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/softdelete"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/version"
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var ErrNoOperations = errors.New("mongox: no operations queued in the bulk writer")
+
+type OperationType string
+
+const (
+	OperationTypeInsertOne  OperationType = "insertOne"
+	OperationTypeUpdateOne  OperationType = "updateOne"
+	OperationTypeUpdateMany OperationType = "updateMany"
+	OperationTypeReplaceOne OperationType = "replaceOne"
+	OperationTypeDeleteOne  OperationType = "deleteOne"
+	OperationTypeDeleteMany OperationType = "deleteMany"
+)
+
+// Operation is an operation queued in the bulk writer
+type Operation[T any] struct {
+	Type   OperationType
+	Filter any
+	// Updates of the update operations
+	Updates any
+	// Doc is the document to insert or the replacement
+	Doc *T
+}
+
+//go:generate mockgen -source=bulkwriter.go -destination=../mock/bulkwriter.mock.go -package=mocks
+type IBulkWriter[T any] interface {
+	InsertOne(doc *T) IBulkWriter[T]
+	UpdateOne(filter, updates any) IBulkWriter[T]
+	UpdateMany(filter, updates any) IBulkWriter[T]
+	ReplaceOne(filter any, replacement *T) IBulkWriter[T]
+	DeleteOne(filter any) IBulkWriter[T]
+	DeleteMany(filter any) IBulkWriter[T]
+	Ordered(ordered bool) IBulkWriter[T]
+	Unscoped() IBulkWriter[T]
+	HardDelete() IBulkWriter[T]
+	Operations() []*Operation[T]
+	Execute(ctx context.Context, opts ...options.Lister[options.BulkWriteOptions]) (*mongo.BulkWriteResult, error)
+	GetCollection() *mongo.Collection
+}
+
+var _ IBulkWriter[any] = (*BulkWriter[any])(nil)
+
+func NewBulkWriter[T any](collection *mongo.Collection, dbCallbacks *callback.Callback, fields []*field.Filed) *BulkWriter[T] {
+	return &BulkWriter[T]{collection: collection, DBCallbacks: dbCallbacks, fields: fields}
+}
+
+type BulkWriter[T any] struct {
+	collection *mongo.Collection
+	fields     []*field.Filed
+
+	operations []*Operation[T]
+	ordered    *bool
+	unscoped   bool
+	hardDelete bool
+
+	DBCallbacks *callback.Callback
+}
+
+func (b *BulkWriter[T]) InsertOne(doc *T) IBulkWriter[T] {
+	return b.add(&Operation[T]{Type: OperationTypeInsertOne, Doc: doc})
+}
+
+func (b *BulkWriter[T]) UpdateOne(filter, updates any) IBulkWriter[T] {
+	return b.add(&Operation[T]{Type: OperationTypeUpdateOne, Filter: filter, Updates: updates})
+}
+
+func (b *BulkWriter[T]) UpdateMany(filter, updates any) IBulkWriter[T] {
+	return b.add(&Operation[T]{Type: OperationTypeUpdateMany, Filter: filter, Updates: updates})
+}
+
+// ReplaceOne queues a replace operation, if the model has a version field,
+// the replacement only matches the document of the same version, which shows up in the MatchedCount of the result.
+// Like Updater.ReplaceOne, the zero _id and create time fields of the replacement keep the values of the replaced document.
+// The fields set by the callbacks, such as the version and the update time, are copied to the replacement once the bulk write succeeds.
+func (b *BulkWriter[T]) ReplaceOne(filter any, replacement *T) IBulkWriter[T] {
+	return b.add(&Operation[T]{Type: OperationTypeReplaceOne, Filter: filter, Doc: replacement})
+}
+
+func (b *BulkWriter[T]) DeleteOne(filter any) IBulkWriter[T] {
+	return b.add(&Operation[T]{Type: OperationTypeDeleteOne, Filter: filter})
+}
+
+func (b *BulkWriter[T]) DeleteMany(filter any) IBulkWriter[T] {
+	return b.add(&Operation[T]{Type: OperationTypeDeleteMany, Filter: filter})
+}
+
+func (b *BulkWriter[T]) add(op *Operation[T]) IBulkWriter[T] {
+	b.operations = append(b.operations, op)
+	return b
+}
+
+// Ordered sets whether the operations are executed in order and stop at the first failure,
+// the driver executes them in order by default
+func (b *BulkWriter[T]) Ordered(ordered bool) IBulkWriter[T] {
+	b.ordered = &ordered
+	return b
+}
+
+// Unscoped makes the update, replace and delete operations also apply to the soft deleted documents
+func (b *BulkWriter[T]) Unscoped() IBulkWriter[T] {
+	b.unscoped = true
+	return b
+}
+
+// HardDelete makes the delete operations remove the documents physically even if the model has a soft delete field
+func (b *BulkWriter[T]) HardDelete() IBulkWriter[T] {
+	b.hardDelete = true
+	return b
+}
+
+// Operations returns the queued operations, the index of an operation is the one reported by its Failure
+func (b *BulkWriter[T]) Operations() []*Operation[T] {
+	return b.operations
+}
+
+// scopedFilter returns the filter sent to the collection,
+// which excludes the soft deleted documents unless Unscoped is called
+func (b *BulkWriter[T]) scopedFilter(filter any) any {
+	return softdelete.ScopeLive(b.fields, filter, b.unscoped)
+}
+
+// Execute runs the queued operations as one bulk write.
+// The beforeInsert, beforeUpdate and beforeDelete callbacks are executed for every operation before the bulk write,
+// so that the field strategies and the model hooks apply to every document,
+// and the after callbacks are executed for every operation once the bulk write succeeds.
+// If some operations fail, the error is a *BulkWriteError whose failures refer to the queued operations.
+func (b *BulkWriter[T]) Execute(ctx context.Context, opts ...options.Lister[options.BulkWriteOptions]) (*mongo.BulkWriteResult, error) {
+	if len(b.operations) == 0 {
+		return nil, ErrNoOperations
+	}
+	currentTime := time.Now()
+	session := mongo.SessionFromContext(ctx)
+
+	models := make([]mongo.WriteModel, 0, len(b.operations))
+	opContexts := make([]*operation.OpContext, 0, len(b.operations))
+	for _, op := range b.operations {
+		opContext, model, err := b.prepare(ctx, op, session, currentTime)
+		if err != nil {
+			return nil, err
+		}
+		opContexts = append(opContexts, opContext)
+		models = append(models, model)
+	}
+
+	if b.ordered != nil {
+		opts = append([]options.Lister[options.BulkWriteOptions]{options.BulkWrite().SetOrdered(*b.ordered)}, opts...)
+	}
+	result, err := b.collection.BulkWrite(ctx, models, opts...)
+	if err != nil {
+		var exception mongo.BulkWriteException
+		if errors.As(err, &exception) {
+			return result, newBulkWriteError(exception, b.operations)
+		}
+		return nil, err
+	}
+
+	for i, op := range b.operations {
+		if replacement, ok := opContexts[i].Doc.(*T); ok && op.Type == OperationTypeReplaceOne && op.Doc != nil {
+			*op.Doc = *replacement
+		}
+		opContexts[i].Result = result
+		if err = b.DBCallbacks.Execute(ctx, opContexts[i], afterOpType(op.Type)); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// prepare executes the before callbacks of the operation and returns the write model of it
+func (b *BulkWriter[T]) prepare(ctx context.Context, op *Operation[T], session *mongo.Session, currentTime time.Time) (*operation.OpContext, mongo.WriteModel, error) {
+	var (
+		opContext *operation.OpContext
+		model     mongo.WriteModel
+		opType    operation.OpType
+		filter    any
+		kept      []string
+	)
+	switch op.Type {
+	case OperationTypeInsertOne:
+		opContext = operation.NewOpContext(b.collection, operation.WithSession(session), operation.WithDoc(op.Doc), operation.WithReflectValue(reflect.ValueOf(op.Doc)), operation.WithFields(b.fields), operation.WithStartTime(currentTime))
+		model = mongo.NewInsertOneModel().SetDocument(op.Doc)
+		opType = operation.OpTypeBeforeInsert
+	case OperationTypeUpdateOne, OperationTypeUpdateMany:
+		filter = b.scopedFilter(op.Filter)
+		updates := op.Updates
+		if m := bsonx.ToBsonM(updates); len(m) != 0 {
+			updates = m
+		}
+		opContext = operation.NewOpContext(b.collection, operation.WithSession(session), operation.WithDoc(new(T)), operation.WithFilter(filter), operation.WithUpdates(updates), operation.WithFields(b.fields), operation.WithStartTime(currentTime))
+		opType = operation.OpTypeBeforeUpdate
+	case OperationTypeReplaceOne:
+		filter := b.scopedFilter(op.Filter)
+		// the callbacks modify a copy of the replacement, which is copied back by Execute once it is written
+		replacement := op.Doc
+		if replacement != nil {
+			cp := *replacement
+			replacement = &cp
+		}
+		// the replacement only matches the document of its version, the version is incremented by the callbacks
+		if fd := version.Field(b.fields); fd != nil {
+			if current, ok := version.Current(b.fields, replacement); ok {
+				filter = version.Scope(filter, fd, current)
+			}
+		}
+		// the replacement is the updates of the operation, so that its update time fields are refreshed
+		opContext = operation.NewOpContext(b.collection, operation.WithSession(session), operation.WithDoc(replacement), operation.WithFilter(filter), operation.WithUpdates(replacement), operation.WithFields(b.fields), operation.WithStartTime(currentTime))
+		model = mongo.NewReplaceOneModel().SetFilter(filter).SetReplacement(replacement)
+		opType = operation.OpTypeBeforeUpdate
+		kept = replace.Kept(b.fields, replacement)
+	case OperationTypeDeleteOne, OperationTypeDeleteMany:
+		filter := b.scopedFilter(op.Filter)
+		opContext = operation.NewOpContext(b.collection, operation.WithSession(session), operation.WithFilter(filter), operation.WithFields(b.fields), operation.WithStartTime(currentTime))
+		model = b.deleteModel(op.Type, filter, currentTime)
+		opType = operation.OpTypeBeforeDelete
+	}
+
+	if err := b.DBCallbacks.Execute(ctx, opContext, opType); err != nil {
+		return nil, nil, err
+	}
+	// the updates are built after the callbacks, which may replace them, such as a pipeline with the update time stage appended
+	switch op.Type {
+	case OperationTypeUpdateOne:
+		model = mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(opContext.Updates)
+	case OperationTypeUpdateMany:
+		model = mongo.NewUpdateManyModel().SetFilter(filter).SetUpdate(opContext.Updates)
+	}
+	// the replacement is turned into an update pipeline which keeps the stored values of the kept fields,
+	// once the callbacks have modified it
+	if len(kept) > 0 {
+		pipeline, err := replace.Pipeline(b.fields, opContext.Doc, kept)
+		if err != nil {
+			return nil, nil, err
+		}
+		replaceModel := model.(*mongo.ReplaceOneModel)
+		model = mongo.NewUpdateOneModel().SetFilter(replaceModel.Filter).SetUpdate(pipeline)
+	}
+	return opContext, model, nil
+}
+
+// deleteModel returns the write model of the delete operation, which is an update if the documents are soft deleted
+func (b *BulkWriter[T]) deleteModel(opType OperationType, filter any, currentTime time.Time) mongo.WriteModel {
+	var fd *field.Filed
+	if !b.hardDelete {
+		fd = softdelete.Field(b.fields)
+	}
+	switch {
+	case fd != nil && opType == OperationTypeDeleteOne:
+		return mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(softdelete.Updates(fd, currentTime))
+	case fd != nil:
+		return mongo.NewUpdateManyModel().SetFilter(filter).SetUpdate(softdelete.Updates(fd, currentTime))
+	case opType == OperationTypeDeleteOne:
+		return mongo.NewDeleteOneModel().SetFilter(filter)
+	default:
+		return mongo.NewDeleteManyModel().SetFilter(filter)
+	}
+}
+
+func afterOpType(opType OperationType) operation.OpType {
+	switch opType {
+	case OperationTypeInsertOne:
+		return operation.OpTypeAfterInsert
+	case OperationTypeDeleteOne, OperationTypeDeleteMany:
+		return operation.OpTypeAfterDelete
+	default:
+		return operation.OpTypeAfterUpdate
+	}
+}
+
+func (b *BulkWriter[T]) GetCollection() *mongo.Collection {
+	return b.collection
+}
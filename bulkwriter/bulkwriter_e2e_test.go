@@ -0,0 +1,117 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build e2e
+
+package bulkwriter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/builder/query"
+	"github.com/chenmingyong0423/go-mongox/v2/builder/update"
+	"github.com/chenmingyong0423/go-mongox/v2/callback"
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+)
+
+func newCollection(t *testing.T) *mongo.Collection {
+	client, err := mongo.Connect(options.Client().ApplyURI("mongodb://localhost:27017").SetAuth(options.Credential{
+		Username:   "test",
+		Password:   "test",
+		AuthSource: "db-test",
+	}))
+	assert.NoError(t, err)
+	assert.NoError(t, client.Ping(context.Background(), readpref.Primary()))
+
+	collection := client.Database("db-test").Collection("test_user")
+	return collection
+}
+
+func TestBulkWriter_e2e_Execute(t *testing.T) {
+	collection := newCollection(t)
+	ctx := context.Background()
+	defer func() {
+		_, err := collection.DeleteMany(ctx, bson.D{})
+		require.NoError(t, err)
+	}()
+
+	createdAt := time.Now().Add(-time.Hour).Truncate(time.Millisecond).UTC()
+	existing := &TestUser{ID: bson.NewObjectID(), Name: "existing", CreatedAt: createdAt}
+	_, err := collection.InsertOne(ctx, existing)
+	require.NoError(t, err)
+
+	b := NewBulkWriter[TestUser](collection, callback.InitializeCallbacks(), field.ParseFields(TestUser{}))
+	inserted := &TestUser{Name: "cmy"}
+	replacement := &TestUser{Name: "replaced"}
+	result, err := b.InsertOne(inserted).
+		UpdateOne(query.Eq("name", "cmy"), update.Set("name", "chenmingyong")).
+		ReplaceOne(query.Id(existing.ID), replacement).
+		DeleteMany(query.Eq("name", "nobody")).
+		Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.InsertedCount)
+	assert.Equal(t, int64(2), result.ModifiedCount)
+	assert.False(t, inserted.ID.IsZero())
+	assert.False(t, inserted.CreatedAt.IsZero())
+	assert.False(t, replacement.UpdatedAt.IsZero())
+
+	user := new(TestUser)
+	require.NoError(t, collection.FindOne(ctx, query.Id(inserted.ID)).Decode(user))
+	assert.Equal(t, "chenmingyong", user.Name)
+	assert.False(t, user.UpdatedAt.IsZero())
+
+	// the zero create time of the replacement keeps the stored value
+	replaced := new(TestUser)
+	require.NoError(t, collection.FindOne(ctx, query.Id(existing.ID)).Decode(replaced))
+	assert.Equal(t, "replaced", replaced.Name)
+	assert.Equal(t, createdAt, replaced.CreatedAt.UTC())
+}
+
+func TestBulkWriter_e2e_Failures(t *testing.T) {
+	collection := newCollection(t)
+	ctx := context.Background()
+	defer func() {
+		_, err := collection.DeleteMany(ctx, bson.D{})
+		require.NoError(t, err)
+	}()
+
+	duplicate := &TestUser{ID: bson.NewObjectID(), Name: "cmy"}
+	_, err := collection.InsertOne(ctx, duplicate)
+	require.NoError(t, err)
+
+	b := NewBulkWriter[TestUser](collection, callback.InitializeCallbacks(), field.ParseFields(TestUser{}))
+	result, err := b.InsertOne(&TestUser{Name: "first"}).
+		InsertOne(&TestUser{ID: duplicate.ID, Name: "duplicate"}).
+		InsertOne(&TestUser{Name: "last"}).
+		Ordered(false).
+		Execute(ctx)
+	require.Error(t, err)
+	assert.Equal(t, int64(2), result.InsertedCount)
+
+	var bulkWriteErr *BulkWriteError[TestUser]
+	require.True(t, errors.As(err, &bulkWriteErr))
+	require.Len(t, bulkWriteErr.Failures, 1)
+	assert.Equal(t, 1, bulkWriteErr.Failures[0].Index)
+	assert.Equal(t, "duplicate", bulkWriteErr.Failures[0].Operation.Doc.Name)
+	assert.True(t, mongo.IsDuplicateKeyError(err))
+}
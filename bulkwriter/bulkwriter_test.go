@@ -0,0 +1,216 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkwriter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/callback"
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/replace"
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type TestUser struct {
+	ID        bson.ObjectID `bson:"_id,omitempty" mongox:"autoID"`
+	Name      string        `bson:"name"`
+	CreatedAt time.Time     `bson:"created_at"`
+	UpdatedAt time.Time     `bson:"updated_at"`
+}
+
+type softDeleteUser struct {
+	ID        bson.ObjectID `bson:"_id,omitempty"`
+	DeletedAt int64         `bson:"deleted_at"`
+}
+
+func TestNewBulkWriter(t *testing.T) {
+	collection := &mongo.Collection{}
+	b := NewBulkWriter[TestUser](collection, nil, nil)
+	assert.NotNil(t, b)
+	assert.Equal(t, collection, b.GetCollection())
+}
+
+func TestBulkWriter_Operations(t *testing.T) {
+	doc := &TestUser{Name: "cmy"}
+	b := NewBulkWriter[TestUser](&mongo.Collection{}, nil, nil)
+	b.InsertOne(doc).
+		UpdateOne(bson.D{{Key: "name", Value: "cmy"}}, bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "chenmingyong"}}}}).
+		UpdateMany(bson.D{}, bson.M{"$set": bson.M{"name": "chenmingyong"}}).
+		ReplaceOne(bson.D{{Key: "name", Value: "cmy"}}, doc).
+		DeleteOne(bson.D{{Key: "name", Value: "cmy"}}).
+		DeleteMany(bson.D{}).
+		Ordered(false)
+
+	assert.Equal(t, []*Operation[TestUser]{
+		{Type: OperationTypeInsertOne, Doc: doc},
+		{Type: OperationTypeUpdateOne, Filter: bson.D{{Key: "name", Value: "cmy"}}, Updates: bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "chenmingyong"}}}}},
+		{Type: OperationTypeUpdateMany, Filter: bson.D{}, Updates: bson.M{"$set": bson.M{"name": "chenmingyong"}}},
+		{Type: OperationTypeReplaceOne, Filter: bson.D{{Key: "name", Value: "cmy"}}, Doc: doc},
+		{Type: OperationTypeDeleteOne, Filter: bson.D{{Key: "name", Value: "cmy"}}},
+		{Type: OperationTypeDeleteMany, Filter: bson.D{}},
+	}, b.Operations())
+	require.NotNil(t, b.ordered)
+	assert.False(t, *b.ordered)
+}
+
+func TestBulkWriter_Execute_NoOperations(t *testing.T) {
+	b := NewBulkWriter[TestUser](&mongo.Collection{}, callback.InitializeCallbacks(), nil)
+	result, err := b.Execute(context.Background())
+	assert.Equal(t, ErrNoOperations, err)
+	assert.Nil(t, result)
+}
+
+func TestBulkWriter_prepare(t *testing.T) {
+	currentTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := NewBulkWriter[TestUser](&mongo.Collection{}, callback.InitializeCallbacks(), field.ParseFields(TestUser{}))
+
+	doc := &TestUser{Name: "cmy"}
+	_, model, err := b.prepare(context.Background(), &Operation[TestUser]{Type: OperationTypeInsertOne, Doc: doc}, nil, currentTime)
+	require.NoError(t, err)
+	assert.False(t, doc.ID.IsZero())
+	assert.Equal(t, currentTime, doc.CreatedAt)
+	assert.Equal(t, currentTime, doc.UpdatedAt)
+	assert.Equal(t, doc, model.(*mongo.InsertOneModel).Document)
+
+	_, model, err = b.prepare(context.Background(), &Operation[TestUser]{Type: OperationTypeUpdateOne, Filter: bson.D{}, Updates: bson.D{{Key: "$set", Value: bson.M{"name": "chenmingyong"}}}}, nil, currentTime)
+	require.NoError(t, err)
+	assert.Equal(t, bson.M{"$set": bson.M{"name": "chenmingyong", "updated_at": currentTime}}, model.(*mongo.UpdateOneModel).Update)
+
+	_, model, err = b.prepare(context.Background(), &Operation[TestUser]{Type: OperationTypeUpdateMany, Filter: bson.D{}, Updates: bson.M{"$set": bson.M{"name": "chenmingyong"}}}, nil, currentTime)
+	require.NoError(t, err)
+	assert.Equal(t, bson.M{"$set": bson.M{"name": "chenmingyong", "updated_at": currentTime}}, model.(*mongo.UpdateManyModel).Update)
+
+	// the stage of the update time is appended to the pipeline by the callbacks
+	_, model, err = b.prepare(context.Background(), &Operation[TestUser]{Type: OperationTypeUpdateOne, Filter: bson.D{}, Updates: mongo.Pipeline{{{Key: "$set", Value: bson.D{{Key: "name", Value: "chenmingyong"}}}}}}, nil, currentTime)
+	require.NoError(t, err)
+	assert.Equal(t, mongo.NewUpdateOneModel().SetFilter(bson.D{}).SetUpdate(mongo.Pipeline{
+		{{Key: "$set", Value: bson.D{{Key: "name", Value: "chenmingyong"}}}},
+		{{Key: "$set", Value: bson.D{{Key: "updated_at", Value: currentTime}}}},
+	}), model)
+
+	_, model, err = b.prepare(context.Background(), &Operation[TestUser]{Type: OperationTypeUpdateMany, Filter: bson.D{}, Updates: bson.A{bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "chenmingyong"}}}}}}, nil, currentTime)
+	require.NoError(t, err)
+	assert.Equal(t, mongo.NewUpdateManyModel().SetFilter(bson.D{}).SetUpdate(bson.A{
+		bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "chenmingyong"}}}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "updated_at", Value: currentTime}}}},
+	}), model)
+
+	replacement := &TestUser{Name: "cmy", UpdatedAt: currentTime.Add(-time.Hour)}
+	opContext, model, err := b.prepare(context.Background(), &Operation[TestUser]{Type: OperationTypeReplaceOne, Filter: bson.D{}, Doc: replacement}, nil, currentTime)
+	require.NoError(t, err)
+	// the callbacks modify a copy of the replacement
+	assert.Equal(t, currentTime.Add(-time.Hour), replacement.UpdatedAt)
+	written := opContext.Doc.(*TestUser)
+	assert.Equal(t, currentTime, written.UpdatedAt)
+	assert.True(t, written.CreatedAt.IsZero())
+	// the zero _id and create time keep the stored values
+	pipeline, err := replace.Pipeline(b.fields, written, []string{"_id", "created_at"})
+	require.NoError(t, err)
+	assert.Equal(t, mongo.NewUpdateOneModel().SetFilter(bson.D{}).SetUpdate(pipeline), model)
+
+	replacement = &TestUser{ID: bson.NewObjectID(), Name: "cmy", CreatedAt: currentTime.Add(-time.Hour)}
+	opContext, model, err = b.prepare(context.Background(), &Operation[TestUser]{Type: OperationTypeReplaceOne, Filter: bson.D{}, Doc: replacement}, nil, currentTime)
+	require.NoError(t, err)
+	assert.Equal(t, mongo.NewReplaceOneModel().SetFilter(bson.D{}).SetReplacement(opContext.Doc), model)
+
+	_, model, err = b.prepare(context.Background(), &Operation[TestUser]{Type: OperationTypeDeleteOne, Filter: bson.D{}}, nil, currentTime)
+	require.NoError(t, err)
+	assert.IsType(t, &mongo.DeleteOneModel{}, model)
+}
+
+func TestBulkWriter_prepare_CallbackError(t *testing.T) {
+	callbacks := callback.InitializeCallbacks()
+	callbacks.Register(operation.OpTypeBeforeDelete, "error", func(ctx context.Context, opCtx *operation.OpContext, opts ...any) error {
+		return errors.New("before delete error")
+	})
+	b := NewBulkWriter[TestUser](&mongo.Collection{}, callbacks, field.ParseFields(TestUser{}))
+	b.InsertOne(&TestUser{}).DeleteOne(bson.D{})
+
+	result, err := b.Execute(context.Background())
+	assert.Equal(t, errors.New("before delete error"), err)
+	assert.Nil(t, result)
+}
+
+func TestBulkWriter_SoftDelete(t *testing.T) {
+	currentTime := time.Now()
+	b := NewBulkWriter[softDeleteUser](&mongo.Collection{}, callback.InitializeCallbacks(), field.ParseFields(softDeleteUser{}))
+	notDeleted := bson.E{Key: "deleted_at", Value: bson.D{{Key: "$in", Value: bson.A{nil, 0}}}}
+
+	_, model, err := b.prepare(context.Background(), &Operation[softDeleteUser]{Type: OperationTypeDeleteOne, Filter: bson.D{}}, nil, currentTime)
+	require.NoError(t, err)
+	assert.Equal(t, mongo.NewUpdateOneModel().SetFilter(bson.D{notDeleted}).SetUpdate(bson.M{"$set": bson.M{"deleted_at": currentTime.Unix()}}), model)
+
+	_, model, err = b.prepare(context.Background(), &Operation[softDeleteUser]{Type: OperationTypeDeleteMany, Filter: bson.D{}}, nil, currentTime)
+	require.NoError(t, err)
+	assert.Equal(t, mongo.NewUpdateManyModel().SetFilter(bson.D{notDeleted}).SetUpdate(bson.M{"$set": bson.M{"deleted_at": currentTime.Unix()}}), model)
+
+	b.HardDelete().Unscoped()
+	_, model, err = b.prepare(context.Background(), &Operation[softDeleteUser]{Type: OperationTypeDeleteMany, Filter: bson.D{}}, nil, currentTime)
+	require.NoError(t, err)
+	assert.Equal(t, mongo.NewDeleteManyModel().SetFilter(bson.D{}), model)
+}
+
+type versionedUser struct {
+	ID      bson.ObjectID `bson:"_id,omitempty"`
+	Version int64         `bson:"version" mongox:"version"`
+}
+
+func TestBulkWriter_Version(t *testing.T) {
+	id := bson.NewObjectID()
+	b := NewBulkWriter[versionedUser](&mongo.Collection{}, callback.InitializeCallbacks(), field.ParseFields(versionedUser{}))
+
+	replacement := &versionedUser{ID: id, Version: 2}
+	opContext, model, err := b.prepare(context.Background(), &Operation[versionedUser]{Type: OperationTypeReplaceOne, Filter: bson.D{{Key: "_id", Value: id}}, Doc: replacement}, nil, time.Now())
+	require.NoError(t, err)
+	// the version of the replacement is only incremented once the bulk write succeeds
+	assert.Equal(t, int64(2), replacement.Version)
+	assert.Equal(t, mongo.NewReplaceOneModel().SetFilter(bson.D{{Key: "_id", Value: id}, {Key: "version", Value: int64(2)}}).SetReplacement(&versionedUser{ID: id, Version: 3}), model)
+	assert.Equal(t, &versionedUser{ID: id, Version: 3}, opContext.Doc)
+
+	_, model, err = b.prepare(context.Background(), &Operation[versionedUser]{Type: OperationTypeUpdateOne, Filter: bson.D{}, Updates: bson.M{"$set": bson.M{"name": "cmy"}}}, nil, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, bson.M{"$set": bson.M{"name": "cmy"}, "$inc": bson.M{"version": 1}}, model.(*mongo.UpdateOneModel).Update)
+}
+
+func TestNewBulkWriteError(t *testing.T) {
+	operations := []*Operation[TestUser]{
+		{Type: OperationTypeInsertOne, Doc: &TestUser{Name: "cmy"}},
+		{Type: OperationTypeDeleteOne, Filter: bson.D{}},
+	}
+	exception := mongo.BulkWriteException{
+		WriteErrors: []mongo.BulkWriteError{
+			{WriteError: mongo.WriteError{Index: 0, Code: 11000, Message: "duplicate key"}},
+			{WriteError: mongo.WriteError{Index: 5, Code: 1, Message: "unknown"}},
+		},
+	}
+
+	err := newBulkWriteError(exception, operations)
+	assert.Equal(t, []Failure[TestUser]{
+		{Index: 0, Operation: operations[0], Err: mongo.WriteError{Index: 0, Code: 11000, Message: "duplicate key"}},
+		{Index: 5, Err: mongo.WriteError{Index: 5, Code: 1, Message: "unknown"}},
+	}, err.Failures)
+	assert.Equal(t, exception.Error(), err.Error())
+
+	var target mongo.BulkWriteException
+	assert.True(t, errors.As(err, &target))
+	assert.True(t, mongo.IsDuplicateKeyError(err))
+}
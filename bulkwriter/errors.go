@@ -0,0 +1,57 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkwriter
+
+import (
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Failure is a queued operation that failed in the bulk write
+type Failure[T any] struct {
+	// Index of the operation in the queue
+	Index     int
+	Operation *Operation[T]
+	Err       mongo.WriteError
+}
+
+// BulkWriteError is returned by Execute when some of the operations failed,
+// it unwraps to the mongo.BulkWriteException returned by the driver.
+type BulkWriteError[T any] struct {
+	Failures []Failure[T]
+	// WriteConcernError is the write concern error of the bulk write, nil if there is none
+	WriteConcernError *mongo.WriteConcernError
+
+	exception mongo.BulkWriteException
+}
+
+func newBulkWriteError[T any](exception mongo.BulkWriteException, operations []*Operation[T]) *BulkWriteError[T] {
+	failures := make([]Failure[T], 0, len(exception.WriteErrors))
+	for _, writeErr := range exception.WriteErrors {
+		failure := Failure[T]{Index: writeErr.Index, Err: writeErr.WriteError}
+		if writeErr.Index >= 0 && writeErr.Index < len(operations) {
+			failure.Operation = operations[writeErr.Index]
+		}
+		failures = append(failures, failure)
+	}
+	return &BulkWriteError[T]{Failures: failures, WriteConcernError: exception.WriteConcernError, exception: exception}
+}
+
+func (e *BulkWriteError[T]) Error() string {
+	return e.exception.Error()
+}
+
+func (e *BulkWriteError[T]) Unwrap() error {
+	return e.exception
+}
@@ -142,6 +142,38 @@ func InitializeCallbacks() *Callback {
 				},
 			},
 		},
+		beforeCount: []callbackHandler{
+			{
+				name: "mongox:model",
+				fn: func(ctx context.Context, opCtx *operation.OpContext, opts ...any) error {
+					return model.Execute(ctx, opCtx, operation.OpTypeBeforeCount, opts...)
+				},
+			},
+		},
+		afterCount: []callbackHandler{
+			{
+				name: "mongox:model",
+				fn: func(ctx context.Context, opCtx *operation.OpContext, opts ...any) error {
+					return model.Execute(ctx, opCtx, operation.OpTypeAfterCount, opts...)
+				},
+			},
+		},
+		beforeDistinct: []callbackHandler{
+			{
+				name: "mongox:model",
+				fn: func(ctx context.Context, opCtx *operation.OpContext, opts ...any) error {
+					return model.Execute(ctx, opCtx, operation.OpTypeBeforeDistinct, opts...)
+				},
+			},
+		},
+		afterDistinct: []callbackHandler{
+			{
+				name: "mongox:model",
+				fn: func(ctx context.Context, opCtx *operation.OpContext, opts ...any) error {
+					return model.Execute(ctx, opCtx, operation.OpTypeAfterDistinct, opts...)
+				},
+			},
+		},
 	}
 }
 
@@ -158,6 +190,19 @@ type Callback struct {
 	afterFind       []callbackHandler
 	beforeAggregate []callbackHandler
 	afterAggregate  []callbackHandler
+	beforeCount     []callbackHandler
+	afterCount      []callbackHandler
+	beforeDistinct  []callbackHandler
+	afterDistinct   []callbackHandler
+
+	// parent is the callback derived from, its handlers are executed after the ones of the derived callback
+	parent *Callback
+}
+
+// Derive returns a callback inheriting the handlers of c, including the ones registered to c later.
+// The handlers registered to the derived callback are executed before the ones of c and don't affect c.
+func (c *Callback) Derive() *Callback {
+	return &Callback{parent: c}
 }
 
 func (c *Callback) BeforeInsert() []callbackHandler {
@@ -208,7 +253,33 @@ func (c *Callback) AfterAggregate() []callbackHandler {
 	return c.afterAggregate
 }
 
+func (c *Callback) BeforeCount() []callbackHandler {
+	return c.beforeCount
+}
+
+func (c *Callback) AfterCount() []callbackHandler {
+	return c.afterCount
+}
+
+func (c *Callback) BeforeDistinct() []callbackHandler {
+	return c.beforeDistinct
+}
+
+func (c *Callback) AfterDistinct() []callbackHandler {
+	return c.afterDistinct
+}
+
 func (c *Callback) Execute(ctx context.Context, opCtx *operation.OpContext, opType operation.OpType, opts ...any) error {
+	if err := c.executeOwn(ctx, opCtx, opType, opts...); err != nil {
+		return err
+	}
+	if c.parent != nil {
+		return c.parent.Execute(ctx, opCtx, opType, opts...)
+	}
+	return nil
+}
+
+func (c *Callback) executeOwn(ctx context.Context, opCtx *operation.OpContext, opType operation.OpType, opts ...any) error {
 	switch opType {
 	case operation.OpTypeBeforeInsert:
 		return c.execute(ctx, opCtx, c.beforeInsert, opts...)
@@ -234,6 +305,14 @@ func (c *Callback) Execute(ctx context.Context, opCtx *operation.OpContext, opTy
 		return c.execute(ctx, opCtx, c.beforeAggregate, opts...)
 	case operation.OpTypeAfterAggregate:
 		return c.execute(ctx, opCtx, c.afterAggregate, opts...)
+	case operation.OpTypeBeforeCount:
+		return c.execute(ctx, opCtx, c.beforeCount, opts...)
+	case operation.OpTypeAfterCount:
+		return c.execute(ctx, opCtx, c.afterCount, opts...)
+	case operation.OpTypeBeforeDistinct:
+		return c.execute(ctx, opCtx, c.beforeDistinct, opts...)
+	case operation.OpTypeAfterDistinct:
+		return c.execute(ctx, opCtx, c.afterDistinct, opts...)
 	}
 	return nil
 }
@@ -309,6 +388,26 @@ func (c *Callback) Register(opType operation.OpType, name string, fn CbFn) {
 			name: name,
 			fn:   fn,
 		})
+	case operation.OpTypeBeforeCount:
+		c.beforeCount = append(c.beforeCount, callbackHandler{
+			name: name,
+			fn:   fn,
+		})
+	case operation.OpTypeAfterCount:
+		c.afterCount = append(c.afterCount, callbackHandler{
+			name: name,
+			fn:   fn,
+		})
+	case operation.OpTypeBeforeDistinct:
+		c.beforeDistinct = append(c.beforeDistinct, callbackHandler{
+			name: name,
+			fn:   fn,
+		})
+	case operation.OpTypeAfterDistinct:
+		c.afterDistinct = append(c.afterDistinct, callbackHandler{
+			name: name,
+			fn:   fn,
+		})
 	case operation.OpTypeBeforeAny:
 		c.beforeInsert = append(c.beforeInsert, callbackHandler{
 			name: name,
@@ -334,6 +433,14 @@ func (c *Callback) Register(opType operation.OpType, name string, fn CbFn) {
 			name: name,
 			fn:   fn,
 		})
+		c.beforeCount = append(c.beforeCount, callbackHandler{
+			name: name,
+			fn:   fn,
+		})
+		c.beforeDistinct = append(c.beforeDistinct, callbackHandler{
+			name: name,
+			fn:   fn,
+		})
 	case operation.OpTypeAfterAny:
 		c.afterInsert = append(c.afterInsert, callbackHandler{
 			name: name,
@@ -359,6 +466,14 @@ func (c *Callback) Register(opType operation.OpType, name string, fn CbFn) {
 			name: name,
 			fn:   fn,
 		})
+		c.afterCount = append(c.afterCount, callbackHandler{
+			name: name,
+			fn:   fn,
+		})
+		c.afterDistinct = append(c.afterDistinct, callbackHandler{
+			name: name,
+			fn:   fn,
+		})
 	}
 }
 
@@ -388,6 +503,14 @@ func (c *Callback) Remove(opType operation.OpType, name string) {
 		c.beforeAggregate = c.remove(c.beforeAggregate, name)
 	case operation.OpTypeAfterAggregate:
 		c.afterAggregate = c.remove(c.afterAggregate, name)
+	case operation.OpTypeBeforeCount:
+		c.beforeCount = c.remove(c.beforeCount, name)
+	case operation.OpTypeAfterCount:
+		c.afterCount = c.remove(c.afterCount, name)
+	case operation.OpTypeBeforeDistinct:
+		c.beforeDistinct = c.remove(c.beforeDistinct, name)
+	case operation.OpTypeAfterDistinct:
+		c.afterDistinct = c.remove(c.afterDistinct, name)
 	case operation.OpTypeBeforeAny:
 		c.beforeInsert = c.remove(c.beforeInsert, name)
 		c.beforeUpdate = c.remove(c.beforeUpdate, name)
@@ -395,6 +518,8 @@ func (c *Callback) Remove(opType operation.OpType, name string) {
 		c.beforeUpsert = c.remove(c.beforeUpsert, name)
 		c.beforeFind = c.remove(c.beforeFind, name)
 		c.beforeAggregate = c.remove(c.beforeAggregate, name)
+		c.beforeCount = c.remove(c.beforeCount, name)
+		c.beforeDistinct = c.remove(c.beforeDistinct, name)
 	case operation.OpTypeAfterAny:
 		c.afterInsert = c.remove(c.afterInsert, name)
 		c.afterUpdate = c.remove(c.afterUpdate, name)
@@ -402,6 +527,8 @@ func (c *Callback) Remove(opType operation.OpType, name string) {
 		c.afterUpsert = c.remove(c.afterUpsert, name)
 		c.afterFind = c.remove(c.afterFind, name)
 		c.afterAggregate = c.remove(c.afterAggregate, name)
+		c.afterCount = c.remove(c.afterCount, name)
+		c.afterDistinct = c.remove(c.afterDistinct, name)
 	}
 }
 
@@ -0,0 +1,249 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongox
+
+import (
+	"context"
+	"sync"
+
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type OperationType string
+
+// The operation types of the change events
+const (
+	OperationTypeInsert       OperationType = "insert"
+	OperationTypeUpdate       OperationType = "update"
+	OperationTypeReplace      OperationType = "replace"
+	OperationTypeDelete       OperationType = "delete"
+	OperationTypeDrop         OperationType = "drop"
+	OperationTypeRename       OperationType = "rename"
+	OperationTypeDropDatabase OperationType = "dropDatabase"
+	OperationTypeInvalidate   OperationType = "invalidate"
+)
+
+// ChangeEvent is a change event of the collection whose full document is decoded as *T
+type ChangeEvent[T any] struct {
+	// ID is the resume token of the event
+	ID            bson.Raw       `bson:"_id"`
+	OperationType OperationType  `bson:"operationType"`
+	ClusterTime   bson.Timestamp `bson:"clusterTime"`
+	DocumentKey   bson.M         `bson:"documentKey"`
+	// FullDocument is nil for the delete events, and for the update events unless
+	// the change stream is opened with options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	FullDocument      *T                 `bson:"fullDocument"`
+	UpdateDescription *UpdateDescription `bson:"updateDescription"`
+}
+
+// UpdateDescription describes the fields updated or removed by an update event
+type UpdateDescription struct {
+	UpdatedFields   bson.M           `bson:"updatedFields"`
+	RemovedFields   []string         `bson:"removedFields"`
+	TruncatedArrays []TruncatedArray `bson:"truncatedArrays"`
+}
+
+// TruncatedArray is an array truncated by an update event
+type TruncatedArray struct {
+	Field   string `bson:"field"`
+	NewSize int32  `bson:"newSize"`
+}
+
+// ResumeTokenStore stores the resume token of a change stream, so that the stream can be resumed after a restart
+type ResumeTokenStore interface {
+	// Load returns the stored resume token, nil if there is none
+	Load(ctx context.Context) (bson.Raw, error)
+	Save(ctx context.Context, token bson.Raw) error
+}
+
+// MemoryResumeTokenStore keeps the resume token in memory,
+// it resumes the change streams reopened in the same process.
+type MemoryResumeTokenStore struct {
+	mu    sync.RWMutex
+	token bson.Raw
+}
+
+func NewMemoryResumeTokenStore() *MemoryResumeTokenStore {
+	return &MemoryResumeTokenStore{}
+}
+
+func (s *MemoryResumeTokenStore) Load(_ context.Context) (bson.Raw, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.token, nil
+}
+
+func (s *MemoryResumeTokenStore) Save(_ context.Context, token bson.Raw) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+	return nil
+}
+
+type watchOptions struct {
+	store ResumeTokenStore
+	opts  []options.Lister[options.ChangeStreamOptions]
+}
+
+type WatchOption func(*watchOptions)
+
+// WithResumeTokenStore resumes the change stream from the token in the store unless WithChangeStreamOptions
+// sets the resume point by SetResumeAfter, SetStartAfter or SetStartAtOperationTime, and saves the token of every event handled to the store
+func WithResumeTokenStore(store ResumeTokenStore) WatchOption {
+	return func(o *watchOptions) {
+		o.store = store
+	}
+}
+
+// WithChangeStreamOptions sets the options of the change stream
+func WithChangeStreamOptions(opts ...options.Lister[options.ChangeStreamOptions]) WatchOption {
+	return func(o *watchOptions) {
+		o.opts = append(o.opts, opts...)
+	}
+}
+
+// Watch opens a change stream on the collection.
+// The pipeline filters or reshapes the events, it can be built by aggregation.StageBuilder, e.g.
+// aggregation.NewStageBuilder().Match(query.In("operationType", "insert", "update")).Build(),
+// and it may be nil to watch all the events.
+func (c *Collection[T]) Watch(ctx context.Context, pipeline any, opts ...WatchOption) (*ChangeStream[T], error) {
+	o := &watchOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if pipeline == nil {
+		pipeline = mongo.Pipeline{}
+	}
+
+	streamOpts := o.opts
+	if o.store != nil {
+		// the resume point given by the caller takes precedence over the stored token,
+		// the server rejects the change stream setting more than one of them
+		resumed, err := hasResumePoint(streamOpts)
+		if err != nil {
+			return nil, err
+		}
+		if !resumed {
+			token, err := o.store.Load(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if token != nil {
+				streamOpts = append(streamOpts, options.ChangeStream().SetStartAfter(token))
+			}
+		}
+	}
+
+	stream, err := c.collection.Watch(ctx, pipeline, streamOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ChangeStream[T]{stream: stream, store: o.store}, nil
+}
+
+// hasResumePoint reports whether the options set where the change stream starts,
+// by SetResumeAfter, SetStartAfter or SetStartAtOperationTime
+func hasResumePoint(opts []options.Lister[options.ChangeStreamOptions]) (bool, error) {
+	args, err := utils.MergeOptions(opts...)
+	if err != nil {
+		return false, err
+	}
+	return args.ResumeAfter != nil || args.StartAfter != nil || args.StartAtOperationTime != nil, nil
+}
+
+// ChangeStream is a change stream whose events are decoded as *ChangeEvent[T]
+type ChangeStream[T any] struct {
+	stream *mongo.ChangeStream
+	store  ResumeTokenStore
+
+	// whether there is an event returned by Next that hasn't been saved to the store
+	pending bool
+	err     error
+}
+
+// Next blocks until the next event is available, it returns false when the stream is closed or an error occurs.
+// If the stream has a resume token store, the token of the previous event is saved before moving to the next one,
+// that is to say an event is considered handled once Next is called again.
+func (s *ChangeStream[T]) Next(ctx context.Context) bool {
+	return s.next(ctx, s.stream.Next)
+}
+
+// TryNext is the same as Next except that it returns false immediately if there is no event available
+func (s *ChangeStream[T]) TryNext(ctx context.Context) bool {
+	return s.next(ctx, s.stream.TryNext)
+}
+
+func (s *ChangeStream[T]) next(ctx context.Context, next func(ctx context.Context) bool) bool {
+	if s.err != nil {
+		return false
+	}
+	if err := s.Save(ctx); err != nil {
+		s.err = err
+		return false
+	}
+	if !next(ctx) {
+		return false
+	}
+	s.pending = true
+	return true
+}
+
+// Event decodes the current event
+func (s *ChangeStream[T]) Event() (*ChangeEvent[T], error) {
+	event := new(ChangeEvent[T])
+	if err := s.stream.Decode(event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Save saves the resume token of the current event to the store, it does nothing without a store.
+func (s *ChangeStream[T]) Save(ctx context.Context) error {
+	if s.store == nil || !s.pending {
+		return nil
+	}
+	if err := s.store.Save(ctx, s.stream.ResumeToken()); err != nil {
+		return err
+	}
+	s.pending = false
+	return nil
+}
+
+// ResumeToken returns the resume token of the current event
+func (s *ChangeStream[T]) ResumeToken() bson.Raw {
+	return s.stream.ResumeToken()
+}
+
+// Err returns the error of the last Next or TryNext
+func (s *ChangeStream[T]) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.stream.Err()
+}
+
+// Close closes the change stream, the token of the current event isn't saved unless Save is called,
+// so the current event is delivered again when the stream is resumed.
+func (s *ChangeStream[T]) Close(ctx context.Context) error {
+	return s.stream.Close(ctx)
+}
+
+// Stream returns the underlying change stream of the driver
+func (s *ChangeStream[T]) Stream() *mongo.ChangeStream {
+	return s.stream
+}
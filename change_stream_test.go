@@ -0,0 +1,145 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongox
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type testEventUser struct {
+	ID   bson.ObjectID `bson:"_id"`
+	Name string        `bson:"name"`
+}
+
+type errResumeTokenStore struct{}
+
+func (errResumeTokenStore) Load(_ context.Context) (bson.Raw, error) {
+	return nil, errors.New("load error")
+}
+
+func (errResumeTokenStore) Save(_ context.Context, _ bson.Raw) error {
+	return errors.New("save error")
+}
+
+func TestChangeEvent_Decode(t *testing.T) {
+	id := bson.NewObjectID()
+	raw, err := bson.Marshal(bson.D{
+		{Key: "_id", Value: bson.D{{Key: "_data", Value: "token"}}},
+		{Key: "operationType", Value: "update"},
+		{Key: "documentKey", Value: bson.D{{Key: "_id", Value: id}}},
+		{Key: "fullDocument", Value: bson.D{{Key: "_id", Value: id}, {Key: "name", Value: "cmy"}}},
+		{Key: "updateDescription", Value: bson.D{
+			{Key: "updatedFields", Value: bson.D{{Key: "name", Value: "cmy"}}},
+			{Key: "removedFields", Value: bson.A{"age"}},
+			{Key: "truncatedArrays", Value: bson.A{bson.D{{Key: "field", Value: "tags"}, {Key: "newSize", Value: int32(1)}}}},
+		}},
+	})
+	require.NoError(t, err)
+
+	event := new(ChangeEvent[testEventUser])
+	require.NoError(t, bson.Unmarshal(raw, event))
+	assert.Equal(t, OperationTypeUpdate, event.OperationType)
+	assert.Equal(t, bson.M{"_id": id}, event.DocumentKey)
+	assert.Equal(t, &testEventUser{ID: id, Name: "cmy"}, event.FullDocument)
+	assert.Equal(t, &UpdateDescription{
+		UpdatedFields:   bson.M{"name": "cmy"},
+		RemovedFields:   []string{"age"},
+		TruncatedArrays: []TruncatedArray{{Field: "tags", NewSize: 1}},
+	}, event.UpdateDescription)
+
+	raw, err = bson.Marshal(bson.D{{Key: "operationType", Value: "delete"}, {Key: "documentKey", Value: bson.D{{Key: "_id", Value: id}}}})
+	require.NoError(t, err)
+	event = new(ChangeEvent[testEventUser])
+	require.NoError(t, bson.Unmarshal(raw, event))
+	assert.Equal(t, OperationTypeDelete, event.OperationType)
+	assert.Nil(t, event.FullDocument)
+	assert.Nil(t, event.UpdateDescription)
+}
+
+func TestMemoryResumeTokenStore(t *testing.T) {
+	store := NewMemoryResumeTokenStore()
+	token, err := store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, token)
+
+	require.NoError(t, store.Save(context.Background(), bson.Raw{1}))
+	token, err = store.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, bson.Raw{1}, token)
+}
+
+func TestCollection_Watch_LoadError(t *testing.T) {
+	collection := NewCollection[testEventUser](NewClient(&mongo.Client{}, &Config{}).NewDatabase("db-test"), "collection-test")
+	stream, err := collection.Watch(context.Background(), nil, WithResumeTokenStore(errResumeTokenStore{}))
+	assert.Equal(t, errors.New("load error"), err)
+	assert.Nil(t, stream)
+}
+
+func Test_hasResumePoint(t *testing.T) {
+	token := bson.Raw{5, 0, 0, 0, 0}
+	testCases := []struct {
+		name string
+		opts []options.Lister[options.ChangeStreamOptions]
+		want bool
+	}{
+		{
+			name: "no options",
+		},
+		{
+			name: "no resume point",
+			opts: []options.Lister[options.ChangeStreamOptions]{nil, options.ChangeStream().SetBatchSize(10)},
+		},
+		{
+			name: "resume after",
+			opts: []options.Lister[options.ChangeStreamOptions]{options.ChangeStream().SetResumeAfter(token)},
+			want: true,
+		},
+		{
+			name: "start after",
+			opts: []options.Lister[options.ChangeStreamOptions]{options.ChangeStream().SetBatchSize(10), options.ChangeStream().SetStartAfter(token)},
+			want: true,
+		},
+		{
+			name: "start at operation time",
+			opts: []options.Lister[options.ChangeStreamOptions]{options.ChangeStream().SetStartAtOperationTime(&bson.Timestamp{T: 1})},
+			want: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := hasResumePoint(tc.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestChangeStream_Save(t *testing.T) {
+	stream := &ChangeStream[testEventUser]{store: errResumeTokenStore{}}
+	// nothing to save before the first event
+	assert.NoError(t, stream.Save(context.Background()))
+
+	stream = &ChangeStream[testEventUser]{}
+	stream.pending = true
+	assert.NoError(t, stream.Save(context.Background()))
+}
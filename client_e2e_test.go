@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
 	"github.com/stretchr/testify/require"
 
 	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
@@ -46,3 +47,34 @@ func TestClient_e2e_NewClient(t *testing.T) {
 	err = client.Disconnect(context.Background())
 	require.NoError(t, err)
 }
+
+func TestClient_e2e_WithSession(t *testing.T) {
+	c, err := mongo.Connect(options.Client().ApplyURI("mongodb://localhost:27017").SetAuth(options.Credential{
+		Username:   "test",
+		Password:   "test",
+		AuthSource: "db-test",
+	}))
+	require.NoError(t, err)
+	client := NewClient(c, &Config{})
+	db := client.NewDatabase("db-test")
+
+	var session *mongo.Session
+	db.RegisterPlugin("session", func(ctx context.Context, opCtx *operation.OpContext, opts ...any) error {
+		session = opCtx.Session
+		return nil
+	}, operation.OpTypeBeforeFind)
+
+	type User struct {
+		Name string `bson:"name"`
+	}
+	err = client.WithSession(context.Background(), func(ctx context.Context) error {
+		_, err := NewCollection[User](db, "test_user").Finder().Find(ctx)
+		if err != nil {
+			return err
+		}
+		require.Equal(t, mongo.SessionFromContext(ctx), session)
+		return nil
+	}, WithCausalConsistency(true))
+	require.NoError(t, err)
+	require.NotNil(t, session)
+}
@@ -0,0 +1,445 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	typedImportPath  = "github.com/chenmingyong0423/go-mongox/v2/builder/typed"
+	mongoxImportPath = "github.com/chenmingyong0423/go-mongox/v2"
+	bsonImportPath   = "go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// maxDepth limits the nesting of the struct fields, which also breaks the cycles of self-referencing types
+const maxDepth = 8
+
+var versionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// generator generates the field paths of the models declared in one package
+type generator struct {
+	fset    *token.FileSet
+	pkgName string
+	// types are the struct types declared in the package
+	types map[string]*typeSpec
+	// imports are the imports used by the generated code, keyed by the path
+	imports map[string]string
+}
+
+type typeSpec struct {
+	spec *ast.TypeSpec
+	file *ast.File
+}
+
+// modelField is a field of a model resolved to its mongo path
+type modelField struct {
+	name string
+	path string
+	// typ is the type of the values of the field in the generated package, elem is the element type of a slice
+	typ, elem string
+	fields    []*modelField
+}
+
+func newGenerator(dir string) (*generator, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("mongox-gen: expected one package in %s, found %d", dir, len(pkgs))
+	}
+
+	g := &generator{fset: fset, types: make(map[string]*typeSpec), imports: make(map[string]string)}
+	for name, pkg := range pkgs {
+		g.pkgName = name
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				genDecl, ok := decl.(*ast.GenDecl)
+				if !ok || genDecl.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range genDecl.Specs {
+					ts := spec.(*ast.TypeSpec)
+					if _, ok := ts.Type.(*ast.StructType); ok {
+						g.types[ts.Name.Name] = &typeSpec{spec: ts, file: file}
+					}
+				}
+			}
+		}
+	}
+	return g, nil
+}
+
+// generate returns the formatted source of the field paths of the models
+func (g *generator) generate(models []string) ([]byte, error) {
+	var body bytes.Buffer
+	for _, model := range models {
+		ts, ok := g.types[model]
+		if !ok {
+			return nil, fmt.Errorf("mongox-gen: struct type %s isn't found in package %s", model, g.pkgName)
+		}
+		if ts.spec.TypeParams != nil {
+			return nil, fmt.Errorf("mongox-gen: generic type %s isn't supported", model)
+		}
+		fields, err := g.fields(ts, "", 0)
+		if err != nil {
+			return nil, err
+		}
+		g.writeModel(&body, model, fields)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by mongox-gen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", g.pkgName)
+	g.imports[typedImportPath] = ""
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	// the standard packages are grouped before the others
+	sort.SliceStable(paths, func(i, j int) bool {
+		return isStd(paths[i]) && !isStd(paths[j])
+	})
+	buf.WriteString("import (\n")
+	for i, path := range paths {
+		if i > 0 && isStd(paths[i-1]) && !isStd(path) {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "\t%s%s\n", g.imports[path], strconv.Quote(path))
+	}
+	buf.WriteString(")\n")
+	buf.Write(body.Bytes())
+	return format.Source(buf.Bytes())
+}
+
+func (g *generator) writeModel(buf *bytes.Buffer, model string, fields []*modelField) {
+	fmt.Fprintf(buf, "\n// The mongo paths of the fields of %s\nconst (\n", model)
+	g.writeConstants(buf, model+"Field", fields)
+	buf.WriteString(")\n")
+
+	fmt.Fprintf(buf, "\n// %sFields are the typed paths of the fields of %s\nvar %sFields = ", model, model, model)
+	g.writeStructType(buf, fields)
+	g.writeStructValue(buf, fields)
+	buf.WriteString("\n")
+}
+
+func (g *generator) writeConstants(buf *bytes.Buffer, prefix string, fields []*modelField) {
+	for _, fd := range fields {
+		fmt.Fprintf(buf, "\t%s%s = %s\n", prefix, fd.name, strconv.Quote(fd.path))
+		g.writeConstants(buf, prefix+fd.name, fd.fields)
+	}
+}
+
+func (g *generator) writeStructType(buf *bytes.Buffer, fields []*modelField) {
+	buf.WriteString("struct {\n")
+	for _, fd := range fields {
+		fmt.Fprintf(buf, "%s ", fd.name)
+		g.writeFieldType(buf, fd)
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}")
+}
+
+// writeFieldType writes the type of the field, a field with nested fields is a struct embedding its typed.Field
+func (g *generator) writeFieldType(buf *bytes.Buffer, fd *modelField) {
+	if fd.fields == nil {
+		buf.WriteString(fieldType(fd))
+		return
+	}
+	buf.WriteString("struct {\n" + fieldType(fd) + "\n")
+	for _, sub := range fd.fields {
+		fmt.Fprintf(buf, "%s ", sub.name)
+		g.writeFieldType(buf, sub)
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}")
+}
+
+func (g *generator) writeStructValue(buf *bytes.Buffer, fields []*modelField) {
+	buf.WriteString("{\n")
+	for _, fd := range fields {
+		fmt.Fprintf(buf, "%s: ", fd.name)
+		g.writeFieldValue(buf, fd)
+		buf.WriteString(",\n")
+	}
+	buf.WriteString("}")
+}
+
+func (g *generator) writeFieldValue(buf *bytes.Buffer, fd *modelField) {
+	if fd.fields == nil {
+		buf.WriteString(fieldConstructor(fd))
+		return
+	}
+	// the anonymous struct type has to be repeated in its composite literal
+	g.writeFieldType(buf, fd)
+	fmt.Fprintf(buf, "{\nField: %s,\n", fieldConstructor(fd))
+	for _, sub := range fd.fields {
+		fmt.Fprintf(buf, "%s: ", sub.name)
+		g.writeFieldValue(buf, sub)
+		buf.WriteString(",\n")
+	}
+	buf.WriteString("}")
+}
+
+func fieldType(fd *modelField) string {
+	if fd.elem != "" {
+		return fmt.Sprintf("typed.Slice[%s]", fd.elem)
+	}
+	return fmt.Sprintf("typed.Field[%s]", fd.typ)
+}
+
+func fieldConstructor(fd *modelField) string {
+	if fd.elem != "" {
+		return fmt.Sprintf("typed.NewSlice[%s](%s)", fd.elem, strconv.Quote(fd.path))
+	}
+	return fmt.Sprintf("typed.NewField[%s](%s)", fd.typ, strconv.Quote(fd.path))
+}
+
+// fields resolves the fields of the struct type the same way as the driver encodes them:
+// the unexported and "-" fields are skipped, the fields without a name in the bson tag are named in lowercase,
+// and the fields of the inlined structs are promoted
+func (g *generator) fields(ts *typeSpec, prefix string, depth int) ([]*modelField, error) {
+	st := ts.spec.Type.(*ast.StructType)
+	result := make([]*modelField, 0, len(st.Fields.List))
+	for _, f := range st.Fields.List {
+		var tag string
+		if f.Tag != nil {
+			unquoted, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+			tag = reflect.StructTag(unquoted).Get("bson")
+		}
+		key, flags, _ := strings.Cut(tag, ",")
+		if key == "-" {
+			continue
+		}
+
+		if strings.Contains(","+flags+",", ",inline,") {
+			if g.isMongoxModel(ts.file, f.Type) {
+				result = append(result, g.mongoxModelFields(prefix)...)
+				continue
+			}
+			inlined, ok := g.localStruct(f.Type)
+			if !ok || depth >= maxDepth {
+				return nil, fmt.Errorf("mongox-gen: the inlined field of type %s in %s must be mongox.Model or a struct declared in package %s", g.expr(f.Type), ts.spec.Name.Name, g.pkgName)
+			}
+			fields, err := g.fields(inlined, prefix, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, fields...)
+			continue
+		}
+
+		names := f.Names
+		if len(names) == 0 {
+			// the embedded field is named by its type
+			names = []*ast.Ident{ast.NewIdent(embeddedName(f.Type))}
+		}
+		for _, name := range names {
+			if !name.IsExported() {
+				continue
+			}
+			path := key
+			if path == "" {
+				path = strings.ToLower(name.Name)
+			}
+			fd, err := g.field(ts, name.Name, prefix+path, f.Type, depth)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, fd)
+		}
+	}
+	return result, nil
+}
+
+func (g *generator) field(ts *typeSpec, name, path string, typ ast.Expr, depth int) (*modelField, error) {
+	fd := &modelField{name: name, path: path, typ: g.typeString(ts.file, typ)}
+	if arr, ok := typ.(*ast.ArrayType); ok && arr.Len == nil && g.expr(arr.Elt) != "byte" {
+		fd.elem = g.typeString(ts.file, arr.Elt)
+	}
+	if fd.elem != "" || depth >= maxDepth {
+		return fd, nil
+	}
+	if nested, ok := g.localStruct(typ); ok {
+		fields, err := g.fields(nested, path+".", depth+1)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) > 0 {
+			fd.fields = fields
+		}
+	}
+	return fd, nil
+}
+
+// localStruct returns the struct type declared in the package which is referred by the expression or its pointer
+func (g *generator) localStruct(typ ast.Expr) (*typeSpec, bool) {
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	ident, ok := typ.(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+	ts, ok := g.types[ident.Name]
+	return ts, ok && ts.spec.TypeParams == nil
+}
+
+// isMongoxModel reports whether the expression refers to mongox.Model or its pointer
+func (g *generator) isMongoxModel(file *ast.File, typ ast.Expr) bool {
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	sel, ok := typ.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Model" {
+		return false
+	}
+	x, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	path, _, ok := importPath(file, x.Name)
+	return ok && path == mongoxImportPath
+}
+
+// mongoxModelFields returns the fields of mongox.Model, which is declared in another package and can't be parsed here
+func (g *generator) mongoxModelFields(prefix string) []*modelField {
+	bsonName := g.qualifier(bsonImportPath)
+	timeName := g.qualifier("time")
+	return []*modelField{
+		{name: "ID", path: prefix + "_id", typ: bsonName + ".ObjectID"},
+		{name: "CreatedAt", path: prefix + "created_at", typ: timeName + ".Time"},
+		{name: "UpdatedAt", path: prefix + "updated_at", typ: timeName + ".Time"},
+		{name: "DeletedAt", path: prefix + "deleted_at", typ: timeName + ".Time"},
+	}
+}
+
+// qualifier records the import and returns the name the generated code refers to it by
+func (g *generator) qualifier(path string) string {
+	if alias, ok := g.imports[path]; ok && alias != "" {
+		return strings.TrimSpace(alias)
+	}
+	g.imports[path] = ""
+	return importName(path)
+}
+
+// typeString returns the type expression in the generated file and records the imports it refers to
+func (g *generator) typeString(file *ast.File, typ ast.Expr) string {
+	ast.Inspect(typ, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if x, ok := sel.X.(*ast.Ident); ok {
+			g.addImport(file, x.Name)
+		}
+		return false
+	})
+	return g.expr(typ)
+}
+
+func (g *generator) addImport(file *ast.File, name string) {
+	path, alias, ok := importPath(file, name)
+	if !ok {
+		return
+	}
+	if alias {
+		g.imports[path] = name + " "
+		return
+	}
+	g.imports[path] = ""
+}
+
+// importPath returns the path of the package imported by the file under the name, and whether the import is renamed
+func importPath(file *ast.File, name string) (string, bool, bool) {
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if spec.Name != nil && spec.Name.Name == name {
+			return path, true, true
+		}
+		if spec.Name == nil && importName(path) == name {
+			return path, false, true
+		}
+	}
+	return "", false, false
+}
+
+func (g *generator) expr(typ ast.Expr) string {
+	var buf bytes.Buffer
+	_ = printer.Fprint(&buf, g.fset, typ)
+	return buf.String()
+}
+
+// importName guesses the name of the package from its import path
+func importName(path string) string {
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if versionSuffix.MatchString(name) && len(elems) > 1 {
+		name = elems[len(elems)-2]
+	}
+	if i := strings.Index(name, ".v"); i > 0 {
+		name = name[:i]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+func isStd(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
+
+func embeddedName(typ ast.Expr) string {
+	switch t := typ.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// defaultOutput returns the file the code is generated into
+func defaultOutput(dir string, models []string) string {
+	if file := os.Getenv("GOFILE"); file != "" {
+		return filepath.Join(dir, strings.TrimSuffix(file, ".go")+"_fields.go")
+	}
+	return filepath.Join(dir, strings.ToLower(models[0])+"_fields.go")
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_generate(t *testing.T) {
+	g, err := newGenerator("testdata/models")
+	require.NoError(t, err)
+	got, err := g.generate([]string{"User", "Address"})
+	require.NoError(t, err)
+
+	// testdata/models/user_fields.go is generated by: go run . -type User,Address testdata/models
+	want, err := os.ReadFile("testdata/models/user_fields.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestGenerator_generate_MongoxModel(t *testing.T) {
+	dir := t.TempDir()
+	src := "package models\n\nimport \"github.com/chenmingyong0423/go-mongox/v2\"\n\ntype User struct {\n\tmongox.Model `bson:\",inline\"`\n\tName string\n}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "model.go"), []byte(src), 0o644))
+	g, err := newGenerator(dir)
+	require.NoError(t, err)
+	got, err := g.generate([]string{"User"})
+	require.NoError(t, err)
+
+	for _, want := range []string{
+		`"time"`,
+		`"go.mongodb.org/mongo-driver/v2/bson"`,
+		`UserFieldID        = "_id"`,
+		`UserFieldCreatedAt = "created_at"`,
+		`UserFieldUpdatedAt = "updated_at"`,
+		`UserFieldDeletedAt = "deleted_at"`,
+		`UserFieldName      = "name"`,
+		`ID:        typed.NewField[bson.ObjectID]("_id"),`,
+		`CreatedAt: typed.NewField[time.Time]("created_at"),`,
+	} {
+		assert.Contains(t, string(got), want)
+	}
+}
+
+func TestGenerator_generate_Error(t *testing.T) {
+	testCases := []struct {
+		name   string
+		src    string
+		models []string
+	}{
+		{
+			name:   "type not found",
+			src:    "package models\n\ntype User struct{}\n",
+			models: []string{"Order"},
+		},
+		{
+			name:   "generic type",
+			src:    "package models\n\ntype User[T any] struct{ Value T }\n",
+			models: []string{"User"},
+		},
+		{
+			name:   "inlined struct of another package",
+			src:    "package models\n\nimport \"time\"\n\ntype User struct {\n\ttime.Location `bson:\",inline\"`\n}\n",
+			models: []string{"User"},
+		},
+		{
+			name:   "inlined map",
+			src:    "package models\n\ntype User struct {\n\tExtra map[string]any `bson:\",inline\"`\n}\n",
+			models: []string{"User"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "model.go"), []byte(tc.src), 0o644))
+			g, err := newGenerator(dir)
+			require.NoError(t, err)
+			_, err = g.generate(tc.models)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_run(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "model.go"), []byte("package models\n\ntype User struct {\n\tName string `bson:\"name\"`\n}\n"), 0o644))
+	t.Setenv("GOFILE", "model.go")
+	require.NoError(t, run(dir, []string{"User"}, ""))
+
+	got, err := os.ReadFile(filepath.Join(dir, "model_fields.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(got), `UserFieldName = "name"`)
+	assert.Contains(t, string(got), `Name: typed.NewField[string]("name"),`)
+}
+
+func Test_importName(t *testing.T) {
+	assert.Equal(t, "bson", importName("go.mongodb.org/mongo-driver/v2/bson"))
+	assert.Equal(t, "mongox", importName("github.com/chenmingyong0423/go-mongox/v2"))
+	assert.Equal(t, "yaml", importName("gopkg.in/yaml.v3"))
+	assert.Equal(t, "time", importName("time"))
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// mongox-gen generates the mongo paths of the fields of the models from their bson tags,
+// both as constants and as typed paths of the builder/typed package:
+//
+//	//go:generate go run github.com/chenmingyong0423/go-mongox/v2/cmd/mongox-gen -type User
+//
+// generates UserFieldAge = "age" and UserFields.Age, so that UserFields.Age.Gte(18) builds the same bson.D as query.Gte("age", 18).
+// The fields of the nested structs declared in the same package are generated too, such as UserFields.Address.City.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-separated list of the model types, required")
+	output := flag.String("output", "", "output file, default is <file>_fields.go for go:generate or <type>_fields.go")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: mongox-gen -type T[,T...] [-output file] [dir]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	models := strings.Split(*typeNames, ",")
+	for i := range models {
+		models[i] = strings.TrimSpace(models[i])
+	}
+
+	if err := run(dir, models, *output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(dir string, models []string, output string) error {
+	g, err := newGenerator(dir)
+	if err != nil {
+		return err
+	}
+	src, err := g.generate(models)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		output = defaultOutput(dir, models)
+	}
+	return os.WriteFile(output, src, 0o644)
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type Base struct {
+	ID        bson.ObjectID `bson:"_id,omitempty"`
+	CreatedAt time.Time     `bson:"created_at"`
+}
+
+type Address struct {
+	City   string `bson:"city"`
+	Street string
+}
+
+type User struct {
+	Base     `bson:",inline"`
+	Name     string `bson:"name"`
+	Age      int
+	Tags     []string `bson:"tags"`
+	Avatar   []byte   `bson:"avatar"`
+	Address  *Address `bson:"address"`
+	Password string   `bson:"-"`
+	secret   string
+	Extra    map[string]any `bson:"extra,omitempty"`
+}
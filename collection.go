@@ -16,11 +16,14 @@ package mongox
 
 import (
 	"github.com/chenmingyong0423/go-mongox/v2/aggregator"
+	"github.com/chenmingyong0423/go-mongox/v2/bulkwriter"
 	"github.com/chenmingyong0423/go-mongox/v2/callback"
 	"github.com/chenmingyong0423/go-mongox/v2/creator"
 	"github.com/chenmingyong0423/go-mongox/v2/deleter"
 	"github.com/chenmingyong0423/go-mongox/v2/field"
 	"github.com/chenmingyong0423/go-mongox/v2/finder"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/strict"
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
 	"github.com/chenmingyong0423/go-mongox/v2/updater"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 )
@@ -44,7 +47,11 @@ type Collection[T any] struct {
 }
 
 func (c *Collection[T]) Finder() *finder.Finder[T] {
-	return finder.NewFinder[T](c.collection, c.callbacks, c.fields)
+	f := finder.NewFinder[T](c.collection, c.callbacks, c.fields)
+	if cfg := c.db.client.config(); cfg != nil && len(cfg.CursorSecret) > 0 {
+		f.CursorSecret(cfg.CursorSecret)
+	}
+	return f
 }
 
 func (c *Collection[T]) Creator() *creator.Creator[T] {
@@ -62,6 +69,37 @@ func (c *Collection[T]) Aggregator() *aggregator.Aggregator[T] {
 	return aggregator.NewAggregator[T](c.collection, c.callbacks, c.fields)
 }
 
+func (c *Collection[T]) BulkWriter() *bulkwriter.BulkWriter[T] {
+	return bulkwriter.NewBulkWriter[T](c.collection, c.callbacks, c.fields)
+}
+
+// Field returns the mongo path of the Go field path of T, such as "Profile.Address.City" to "profile.address.city",
+// field.ErrUnknownField is returned if the path doesn't match the fields of T
+func (c *Collection[T]) Field(goPath string) (string, error) {
+	return field.Resolve(c.fields, goPath)
+}
+
+// Strict returns a copy of the collection which checks the keys of the filters, sorts, projections and updates
+// against the fields of T before the operations are sent to mongo, so that a misspelled field doesn't silently match nothing.
+// The values are checked against the types of the fields as well, such as $inc on a string field.
+// An error wrapping field.ErrUnknownField or field.ErrTypeMismatch is returned when the check fails.
+// The conditions added by the callbacks of the database, such as the ones of the plugins, are not checked.
+func (c *Collection[T]) Strict() *Collection[T] {
+	strictCollection := *c
+	strictCollection.callbacks = c.callbacks.Derive()
+	for _, opType := range []operation.OpType{
+		operation.OpTypeBeforeFind,
+		operation.OpTypeBeforeCount,
+		operation.OpTypeBeforeDistinct,
+		operation.OpTypeBeforeUpdate,
+		operation.OpTypeBeforeUpsert,
+		operation.OpTypeBeforeDelete,
+	} {
+		strictCollection.callbacks.Register(opType, "mongox:strict", strict.Execute)
+	}
+	return &strictCollection
+}
+
 func (c *Collection[T]) Collection() *mongo.Collection {
 	return c.collection
 }
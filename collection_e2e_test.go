@@ -19,7 +19,9 @@ package mongox
 import (
 	"context"
 	"testing"
+	"time"
 
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
@@ -74,3 +76,51 @@ func getCollection[T any](t *testing.T) *Collection[T] {
 	collection := NewCollection[T](NewClient(client, &Config{}).NewDatabase("db-test"), "test_user")
 	return collection
 }
+
+type testIndexUser struct {
+	ID       bson.ObjectID `bson:"_id,omitempty"`
+	Name     string        `bson:"name" mongox:"index"`
+	Email    string        `bson:"email" mongox:"unique"`
+	Age      int           `bson:"age" mongox:"index:idx_age_city,compound"`
+	City     string        `bson:"city" mongox:"index:idx_age_city,compound"`
+	ExpireAt time.Time     `bson:"expire_at" mongox:"ttl:24h"`
+}
+
+func (testIndexUser) Indexes() []mongo.IndexModel {
+	return []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "age", Value: -1}},
+			Options: options.Index().SetName("idx_adult").SetPartialFilterExpression(bson.D{{Key: "age", Value: bson.D{{Key: "$gte", Value: 18}}}}),
+		},
+	}
+}
+
+func TestCollection_e2e_SyncIndexes(t *testing.T) {
+	collection := NewCollection[testIndexUser](getCollection[any](t).db, "test_index_user")
+	ctx := context.Background()
+	defer func() {
+		assert.NoError(t, collection.Collection().Drop(ctx))
+	}()
+	assert.NoError(t, collection.Collection().Drop(ctx))
+
+	_, err := collection.Collection().Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: "nickname", Value: 1}}})
+	assert.NoError(t, err)
+	_, err = collection.Collection().Indexes().CreateOne(ctx, mongo.IndexModel{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetUnique(true)})
+	assert.NoError(t, err)
+
+	result, err := collection.SyncIndexes(ctx)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"email_1", "idx_age_city", "expire_at_1", "idx_adult"}, result.Created)
+	assert.ElementsMatch(t, []string{"nickname_1", "name_1"}, result.Stale)
+	assert.Empty(t, result.Dropped)
+
+	result, err = collection.SyncIndexes(ctx, WithDropStaleIndexes())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name_1"}, result.Created)
+	assert.ElementsMatch(t, []string{"nickname_1", "name_1"}, result.Stale)
+	assert.ElementsMatch(t, []string{"nickname_1", "name_1"}, result.Dropped)
+
+	result, err = collection.SyncIndexes(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &SyncIndexesResult{}, result)
+}
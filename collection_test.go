@@ -15,14 +15,17 @@
 package mongox
 
 import (
+	"context"
 	"testing"
 
 	"github.com/chenmingyong0423/go-mongox/v2/updater"
 
 	"github.com/chenmingyong0423/go-mongox/v2/creator"
 
+	"github.com/chenmingyong0423/go-mongox/v2/field"
 	"github.com/chenmingyong0423/go-mongox/v2/finder"
 	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
@@ -62,3 +65,44 @@ func TestCollection_Collection(t *testing.T) {
 	a := NewCollection[any](NewClient(&mongo.Client{}, &Config{}).NewDatabase("db-test"), "collection-test")
 	assert.NotNil(t, a.Collection(), "Expected non-nil *mongo.Collection")
 }
+
+func TestCollection_BulkWriter(t *testing.T) {
+	b := NewCollection[any](NewClient(&mongo.Client{}, &Config{}).NewDatabase("db-test"), "collection-test").BulkWriter()
+	assert.NotNil(t, b, "Expected non-nil BulkWriter")
+}
+
+func TestCollection_Field(t *testing.T) {
+	type user struct {
+		Name string `bson:"name"`
+	}
+	collection := NewCollection[user](NewClient(&mongo.Client{}, &Config{}).NewDatabase("db-test"), "collection-test")
+
+	key, err := collection.Field("Name")
+	assert.NoError(t, err)
+	assert.Equal(t, "name", key)
+
+	_, err = collection.Field("Age")
+	assert.ErrorIs(t, err, field.ErrUnknownField)
+}
+
+func TestCollection_Strict(t *testing.T) {
+	type user struct {
+		Name string `bson:"name"`
+		Age  int    `bson:"age"`
+	}
+	collection := NewCollection[user](NewClient(&mongo.Client{}, &Config{}).NewDatabase("db-test"), "collection-test")
+	beforeFind := len(collection.callbacks.BeforeFind())
+	strictCollection := collection.Strict()
+
+	assert.Len(t, strictCollection.callbacks.BeforeFind(), 1)
+	assert.Len(t, collection.callbacks.BeforeFind(), beforeFind)
+
+	_, err := strictCollection.Finder().Filter(bson.D{{Key: "nmae", Value: "chenmingyong"}}).FindOne(context.Background())
+	assert.ErrorIs(t, err, field.ErrUnknownField)
+
+	_, err = strictCollection.Updater().Filter(bson.D{{Key: "name", Value: "chenmingyong"}}).Updates(bson.D{{Key: "$inc", Value: bson.D{{Key: "name", Value: 1}}}}).UpdateOne(context.Background())
+	assert.ErrorIs(t, err, field.ErrTypeMismatch)
+
+	_, err = strictCollection.Deleter().Filter(bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: "18"}}}}).DeleteMany(context.Background())
+	assert.ErrorIs(t, err, field.ErrTypeMismatch)
+}
@@ -15,4 +15,7 @@
 package mongox
 
 type Config struct {
+	// CursorSecret signs the tokens of the keyset pagination of the finders.
+	// It must be set to the same value on every instance sharing the tokens, PageByCursor fails without it.
+	CursorSecret []byte
 }
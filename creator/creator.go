@@ -113,7 +113,7 @@ func (c *Creator[T]) InsertOne(ctx context.Context, doc *T, opts ...options.List
 	currentTime := time.Now()
 	docValue := reflect.ValueOf(doc)
 
-	globalOpContext := operation.NewOpContext(c.collection, operation.WithDoc(doc), operation.WithReflectValue(docValue), operation.WithMongoOptions(opts), operation.WithModelHook(c.modelHook), operation.WithStartTime(currentTime), operation.WithFields(c.fields))
+	globalOpContext := operation.NewOpContext(c.collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithDoc(doc), operation.WithReflectValue(docValue), operation.WithMongoOptions(opts), operation.WithModelHook(c.modelHook), operation.WithStartTime(currentTime), operation.WithFields(c.fields))
 	opContext := NewOpContext(c.collection, WithDoc(doc), WithReflectValue[T](docValue), WithStartTime[T](currentTime), WithMongoOptions[T](opts), WithModelHook[T](c.modelHook), WithFields[T](c.fields))
 
 	err := c.preActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeInsert)
@@ -140,7 +140,7 @@ func (c *Creator[T]) InsertMany(ctx context.Context, docs []*T, opts ...options.
 	currentTime := time.Now()
 	docsValue := reflect.ValueOf(docs)
 
-	globalOpContext := operation.NewOpContext(c.collection, operation.WithDoc(docs), operation.WithReflectValue(docsValue), operation.WithStartTime(currentTime), operation.WithMongoOptions(opts), operation.WithModelHook(c.modelHook), operation.WithFields(c.fields))
+	globalOpContext := operation.NewOpContext(c.collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithDoc(docs), operation.WithReflectValue(docsValue), operation.WithStartTime(currentTime), operation.WithMongoOptions(opts), operation.WithModelHook(c.modelHook), operation.WithFields(c.fields))
 	opContext := NewOpContext(c.collection, WithDocs(docs), WithReflectValue[T](docsValue), WithStartTime[T](currentTime), WithMongoOptions[T](opts), WithModelHook[T](c.modelHook), WithFields[T](c.fields))
 
 	err := c.preActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeInsert)
@@ -16,9 +16,13 @@ package deleter
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/softdelete"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
+	"go.mongodb.org/mongo-driver/v2/bson"
 
 	"github.com/chenmingyong0423/go-mongox/v2/callback"
 
@@ -32,8 +36,12 @@ import (
 type IDeleter[T any] interface {
 	DeleteOne(ctx context.Context, opts ...options.Lister[options.DeleteOneOptions]) (*mongo.DeleteResult, error)
 	DeleteMany(ctx context.Context, opts ...options.Lister[options.DeleteManyOptions]) (*mongo.DeleteResult, error)
+	DeleteManyReturning(ctx context.Context, opts ...options.Lister[options.DeleteManyOptions]) ([]*T, error)
 	Filter(filter any) IDeleter[T]
 	ModelHook(modelHook any) IDeleter[T]
+	Unscoped() IDeleter[T]
+	HardDelete() IDeleter[T]
+	Restore(ctx context.Context, opts ...options.Lister[options.UpdateManyOptions]) (*mongo.UpdateResult, error)
 	RegisterAfterHooks(hooks ...AfterHookFn) IDeleter[T]
 	RegisterBeforeHooks(hooks ...BeforeHookFn) IDeleter[T]
 	PostActionHandler(ctx context.Context, globalOpContext *operation.OpContext, opContext *OpContext, opType operation.OpType) error
@@ -43,6 +51,9 @@ type IDeleter[T any] interface {
 
 var _ IDeleter[any] = (*Deleter[any])(nil)
 
+// ErrSoftDeleteNotSupported is returned by Restore when the model doesn't have a soft delete field
+var ErrSoftDeleteNotSupported = errors.New("mongox: the model doesn't have a soft delete field")
+
 func NewDeleter[T any](collection *mongo.Collection, dbCallbacks *callback.Callback, fields []*field.Filed) *Deleter[T] {
 	return &Deleter[T]{collection: collection, DBCallbacks: dbCallbacks, fields: fields}
 }
@@ -51,8 +62,10 @@ type Deleter[T any] struct {
 	collection *mongo.Collection
 	fields     []*field.Filed
 
-	filter    any
-	modelHook any
+	filter     any
+	modelHook  any
+	unscoped   bool
+	hardDelete bool
 
 	DBCallbacks *callback.Callback
 	BeforeHooks []BeforeHookFn
@@ -108,16 +121,48 @@ func (d *Deleter[T]) ModelHook(modelHook any) IDeleter[T] {
 	return d
 }
 
+// Unscoped makes the deletion also match the documents that have been soft deleted
+func (d *Deleter[T]) Unscoped() IDeleter[T] {
+	d.unscoped = true
+	return d
+}
+
+// HardDelete makes the deletion remove the documents physically even if the model has a soft delete field
+func (d *Deleter[T]) HardDelete() IDeleter[T] {
+	d.hardDelete = true
+	return d
+}
+
+// softDeleteField returns the soft delete field of the model, nil if the documents should be removed physically
+func (d *Deleter[T]) softDeleteField() *field.Filed {
+	if d.hardDelete {
+		return nil
+	}
+	return softdelete.Field(d.fields)
+}
+
+// scopedFilter returns the filter sent to the collection,
+// which excludes the soft deleted documents unless Unscoped is called
+func (d *Deleter[T]) scopedFilter() any {
+	return softdelete.ScopeLive(d.fields, d.filter, d.unscoped)
+}
+
 func (d *Deleter[T]) DeleteOne(ctx context.Context, opts ...options.Lister[options.DeleteOneOptions]) (*mongo.DeleteResult, error) {
 	currentTime := time.Now()
-	globalOpContext := operation.NewOpContext(d.collection, operation.WithFilter(d.filter), operation.WithMongoOptions(opts), operation.WithModelHook(d.modelHook), operation.WithFields(d.fields), operation.WithStartTime(currentTime))
-	opContext := NewOpContext(d.collection, d.filter, WithMongoOptions(opts), WithModelHook(d.modelHook), WithFields(d.fields), WithStartTime(currentTime))
+	filter := d.scopedFilter()
+	globalOpContext := operation.NewOpContext(d.collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithFilter(filter), operation.WithMongoOptions(opts), operation.WithModelHook(d.modelHook), operation.WithFields(d.fields), operation.WithStartTime(currentTime))
+	opContext := NewOpContext(d.collection, filter, WithMongoOptions(opts), WithModelHook(d.modelHook), WithFields(d.fields), WithStartTime(currentTime))
 	err := d.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeDelete)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := d.collection.DeleteOne(ctx, d.filter, opts...)
+	var result *mongo.DeleteResult
+	if fd := d.softDeleteField(); fd != nil {
+		result, err = d.softDeleteOne(ctx, filter, fd, currentTime, opts...)
+	} else {
+		result, err = d.collection.DeleteOne(ctx, filter, opts...)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -134,14 +179,20 @@ func (d *Deleter[T]) DeleteOne(ctx context.Context, opts ...options.Lister[optio
 
 func (d *Deleter[T]) DeleteMany(ctx context.Context, opts ...options.Lister[options.DeleteManyOptions]) (*mongo.DeleteResult, error) {
 	currentTime := time.Now()
-	globalOpContext := operation.NewOpContext(d.collection, operation.WithFilter(d.filter), operation.WithMongoOptions(opts), operation.WithModelHook(d.modelHook), operation.WithFields(d.fields), operation.WithStartTime(currentTime))
-	opContext := NewOpContext(d.collection, d.filter, WithMongoOptions(opts), WithModelHook(d.modelHook), WithFields(d.fields), WithStartTime(currentTime))
+	filter := d.scopedFilter()
+	globalOpContext := operation.NewOpContext(d.collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithFilter(filter), operation.WithMongoOptions(opts), operation.WithModelHook(d.modelHook), operation.WithFields(d.fields), operation.WithStartTime(currentTime))
+	opContext := NewOpContext(d.collection, filter, WithMongoOptions(opts), WithModelHook(d.modelHook), WithFields(d.fields), WithStartTime(currentTime))
 	err := d.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeDelete)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := d.collection.DeleteMany(ctx, d.filter, opts...)
+	var result *mongo.DeleteResult
+	if fd := d.softDeleteField(); fd != nil {
+		result, err = d.softDeleteMany(ctx, filter, fd, currentTime, opts...)
+	} else {
+		result, err = d.collection.DeleteMany(ctx, filter, opts...)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -155,6 +206,87 @@ func (d *Deleter[T]) DeleteMany(ctx context.Context, opts ...options.Lister[opti
 
 	return result, nil
 }
+
+// Restore brings back the soft deleted documents matching the filter by unsetting their soft delete field.
+// The beforeUpdate and afterUpdate callbacks are executed, so the update time is refreshed as well.
+func (d *Deleter[T]) Restore(ctx context.Context, opts ...options.Lister[options.UpdateManyOptions]) (*mongo.UpdateResult, error) {
+	currentTime := time.Now()
+	fd := softdelete.Field(d.fields)
+	if fd == nil {
+		return nil, ErrSoftDeleteNotSupported
+	}
+	filter := softdelete.Scope(d.filter, softdelete.Deleted(fd))
+	updates := bson.M{"$unset": bson.M{fd.MongoField: ""}}
+
+	globalOpContext := operation.NewOpContext(d.collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithDoc(new(T)), operation.WithFilter(filter), operation.WithUpdates(updates), operation.WithMongoOptions(opts), operation.WithModelHook(d.modelHook), operation.WithFields(d.fields), operation.WithStartTime(currentTime))
+	err := d.DBCallbacks.Execute(ctx, globalOpContext, operation.OpTypeBeforeUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := d.collection.UpdateMany(ctx, filter, updates, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	globalOpContext.Result = result
+	err = d.DBCallbacks.Execute(ctx, globalOpContext, operation.OpTypeAfterUpdate)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (d *Deleter[T]) softDeleteOne(ctx context.Context, filter any, fd *field.Filed, currentTime time.Time, opts ...options.Lister[options.DeleteOneOptions]) (*mongo.DeleteResult, error) {
+	args, err := utils.MergeOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	updateOpts := options.UpdateOne()
+	if args.Collation != nil {
+		updateOpts.SetCollation(args.Collation)
+	}
+	if args.Comment != nil {
+		updateOpts.SetComment(args.Comment)
+	}
+	if args.Hint != nil {
+		updateOpts.SetHint(args.Hint)
+	}
+	if args.Let != nil {
+		updateOpts.SetLet(args.Let)
+	}
+	result, err := d.collection.UpdateOne(ctx, filter, softdelete.Updates(fd, currentTime), updateOpts)
+	if err != nil {
+		return nil, err
+	}
+	return &mongo.DeleteResult{DeletedCount: result.ModifiedCount, Acknowledged: result.Acknowledged}, nil
+}
+
+func (d *Deleter[T]) softDeleteMany(ctx context.Context, filter any, fd *field.Filed, currentTime time.Time, opts ...options.Lister[options.DeleteManyOptions]) (*mongo.DeleteResult, error) {
+	args, err := utils.MergeOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	updateOpts := options.UpdateMany()
+	if args.Collation != nil {
+		updateOpts.SetCollation(args.Collation)
+	}
+	if args.Comment != nil {
+		updateOpts.SetComment(args.Comment)
+	}
+	if args.Hint != nil {
+		updateOpts.SetHint(args.Hint)
+	}
+	if args.Let != nil {
+		updateOpts.SetLet(args.Let)
+	}
+	result, err := d.collection.UpdateMany(ctx, filter, softdelete.Updates(fd, currentTime), updateOpts)
+	if err != nil {
+		return nil, err
+	}
+	return &mongo.DeleteResult{DeletedCount: result.ModifiedCount, Acknowledged: result.Acknowledged}, nil
+}
+
 func (d *Deleter[T]) GetCollection() *mongo.Collection {
 	return d.collection
 }
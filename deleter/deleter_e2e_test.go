@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/chenmingyong0423/go-mongox/v2/field"
 
@@ -605,3 +606,136 @@ func TestDeleter_e2e_DeleteMany(t *testing.T) {
 		})
 	}
 }
+
+type testSoftDeleteUser struct {
+	Id        string    `bson:"_id"`
+	Name      string    `bson:"name"`
+	DeletedAt time.Time `bson:"deleted_at,omitempty"`
+}
+
+func TestDeleter_e2e_SoftDelete(t *testing.T) {
+	collection := newCollection(t)
+	fields := field.ParseFields(testSoftDeleteUser{})
+	newDeleter := func() *xdeleter.Deleter[testSoftDeleteUser] {
+		return xdeleter.NewDeleter[testSoftDeleteUser](collection, callback.InitializeCallbacks(), fields)
+	}
+	ctx := context.Background()
+
+	_, err := collection.InsertMany(ctx, utils.ToAnySlice([]testSoftDeleteUser{
+		{Id: "1", Name: "Mingyong Chen"},
+		{Id: "2", Name: "Mingyong Chen"},
+	}...))
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Eq("name", "Mingyong Chen").Build())
+		require.NoError(t, err)
+	}()
+
+	// soft delete keeps the document and sets deleted_at
+	result, err := newDeleter().Filter(query.Id("1")).DeleteOne(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.DeletedCount)
+
+	var user testSoftDeleteUser
+	require.NoError(t, collection.FindOne(ctx, query.Id("1")).Decode(&user))
+	require.False(t, user.DeletedAt.IsZero())
+
+	// a soft deleted document is not deleted again
+	result, err = newDeleter().Filter(query.Id("1")).DeleteOne(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), result.DeletedCount)
+
+	// restore brings the document back
+	restoreResult, err := newDeleter().Filter(query.Id("1")).Restore(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), restoreResult.ModifiedCount)
+	user = testSoftDeleteUser{}
+	require.NoError(t, collection.FindOne(ctx, query.Id("1")).Decode(&user))
+	require.True(t, user.DeletedAt.IsZero())
+
+	// hard delete removes the documents physically
+	result, err = newDeleter().Filter(query.Eq("name", "Mingyong Chen")).HardDelete().DeleteMany(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), result.DeletedCount)
+	count, err := collection.CountDocuments(ctx, query.Eq("name", "Mingyong Chen"))
+	require.NoError(t, err)
+	require.Equal(t, int64(0), count)
+}
+
+type returningUser struct {
+	Id   string `bson:"_id"`
+	Name string `bson:"name"`
+	Age  int64  `bson:"age"`
+
+	deleted bool
+}
+
+func (u *returningUser) BeforeDelete(_ context.Context) error {
+	u.deleted = true
+	return nil
+}
+
+func TestDeleter_e2e_DeleteManyReturning(t *testing.T) {
+	collection := newCollection(t)
+	ctx := context.Background()
+	_, err := collection.InsertMany(ctx, []any{
+		&returningUser{Id: "1", Name: "cmy", Age: 18},
+		&returningUser{Id: "2", Name: "chenmingyong", Age: 18},
+		&returningUser{Id: "3", Name: "Mingyong Chen", Age: 24},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Build())
+		require.NoError(t, err)
+	}()
+
+	var hooked any
+	deleter := xdeleter.NewDeleter[returningUser](collection, callback.InitializeCallbacks(), field.ParseFields(returningUser{}))
+	deleter.RegisterAfterHooks(func(_ context.Context, opContext *xdeleter.OpContext, _ ...any) error {
+		hooked = opContext.Docs
+		return nil
+	})
+	docs, err := deleter.Filter(query.Eq("age", 18)).DeleteManyReturning(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*returningUser{
+		{Id: "1", Name: "cmy", Age: 18, deleted: true},
+		{Id: "2", Name: "chenmingyong", Age: 18, deleted: true},
+	}, docs)
+	require.Equal(t, docs, hooked)
+
+	count, err := collection.CountDocuments(ctx, query.NewBuilder().Build())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	docs, err = xdeleter.NewDeleter[returningUser](collection, callback.InitializeCallbacks(), nil).Filter(query.Eq("age", 18)).DeleteManyReturning(ctx)
+	require.NoError(t, err)
+	require.Empty(t, docs)
+}
+
+func TestDeleter_e2e_DeleteManyReturning_NarrowedFilter(t *testing.T) {
+	collection := newCollection(t)
+	ctx := context.Background()
+	_, err := collection.InsertMany(ctx, []any{
+		&returningUser{Id: "1", Name: "cmy", Age: 18},
+		&returningUser{Id: "2", Name: "chenmingyong", Age: 18},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Build())
+		require.NoError(t, err)
+	}()
+
+	// the callbacks narrow the filter, like the ones scoping the documents by the tenant
+	callbacks := callback.InitializeCallbacks()
+	callbacks.Register(operation.OpTypeBeforeDelete, "tenant", func(_ context.Context, opCtx *operation.OpContext, _ ...any) error {
+		opCtx.Filter = query.And(opCtx.Filter, query.Eq("name", "cmy"))
+		return nil
+	})
+	docs, err := xdeleter.NewDeleter[returningUser](collection, callbacks, nil).Filter(query.Eq("age", 18)).DeleteManyReturning(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []*returningUser{{Id: "1", Name: "cmy", Age: 18, deleted: true}}, docs)
+
+	count, err := collection.CountDocuments(ctx, query.Eq("_id", "2"))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+}
@@ -20,11 +20,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/chenmingyong0423/go-mongox/v2/callback"
 	"github.com/chenmingyong0423/go-mongox/v2/deleter"
 	mocks "github.com/chenmingyong0423/go-mongox/v2/mock"
 	"github.com/chenmingyong0423/go-mongox/v2/operation"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
@@ -225,6 +227,78 @@ func TestDeleter_DeleteMany(t *testing.T) {
 	}
 }
 
+func TestDeleter_DeleteManyReturning(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctx context.Context, ctl *gomock.Controller) deleter.IDeleter[TestUser]
+		ctx  context.Context
+
+		want    []*TestUser
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "error: nil filter",
+			mock: func(ctx context.Context, ctl *gomock.Controller) deleter.IDeleter[TestUser] {
+				mockCollection := mocks.NewMockIDeleter[TestUser](ctl)
+				mockCollection.EXPECT().DeleteManyReturning(ctx).Return(nil, errors.New("nil filter")).Times(1)
+				return mockCollection
+			},
+			ctx: context.Background(),
+			wantErr: func(t assert.TestingT, err error, i ...interface{}) bool {
+				return assert.Equal(t, errors.New("nil filter"), err)
+			},
+		},
+		{
+			name: "delete success",
+			mock: func(ctx context.Context, ctl *gomock.Controller) deleter.IDeleter[TestUser] {
+				mockCollection := mocks.NewMockIDeleter[TestUser](ctl)
+				mockCollection.EXPECT().DeleteManyReturning(ctx).Return([]*TestUser{{Name: "cmy"}}, nil).Times(1)
+				return mockCollection
+			},
+			ctx:     context.Background(),
+			want:    []*TestUser{{Name: "cmy"}},
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctl := gomock.NewController(t)
+			defer ctl.Finish()
+
+			got, err := tc.mock(tc.ctx, ctl).DeleteManyReturning(tc.ctx)
+			if !tc.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestDeleter_DeleteManyReturning_BeforeCallbacks(t *testing.T) {
+	client, err := mongo.Connect()
+	require.NoError(t, err)
+	collection := client.Database("db-test").Collection("test_user")
+	errCallback := errors.New("callback error")
+
+	callbacks := callback.InitializeCallbacks()
+	var filter any
+	callbacks.Register(operation.OpTypeBeforeDelete, "test", func(_ context.Context, opCtx *operation.OpContext, _ ...any) error {
+		filter = opCtx.Filter
+		return errCallback
+	})
+	session, err := client.StartSession()
+	require.NoError(t, err)
+	defer session.EndSession(context.Background())
+
+	// the callbacks see the filter before the documents are found, and nothing is sent to the server when they fail
+	docs, err := deleter.NewDeleter[TestUser](collection, callbacks, nil).Filter(bson.D{{Key: "name", Value: "cmy"}}).
+		DeleteManyReturning(mongo.NewSessionContext(context.Background(), session))
+	assert.Equal(t, errCallback, err)
+	assert.Nil(t, docs)
+	assert.Equal(t, bson.D{{Key: "name", Value: "cmy"}}, filter)
+}
+
 func TestDeleter_Filter(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -0,0 +1,124 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deleter
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/internal/hook/model"
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// the code of the error returned by a server which doesn't support transactions, such as a standalone server
+const illegalOperationCode = 20
+
+// DeleteManyReturning deletes the documents matching the filter and returns them.
+// The documents are found first and then deleted by the filter and their _id, both in a transaction unless ctx already carries a session,
+// in which case the session of the caller is used. Without transaction support, the two steps aren't atomic,
+// and a document which no longer matches the filter when it is deleted is returned without being deleted.
+// The before delete callbacks are executed on the filter before the documents are found, so that they can narrow it,
+// the BeforeDelete hooks of the documents found are executed before they are deleted,
+// and the after delete callbacks and hooks are executed with the documents found.
+func (d *Deleter[T]) DeleteManyReturning(ctx context.Context, opts ...options.Lister[options.DeleteManyOptions]) ([]*T, error) {
+	if mongo.SessionFromContext(ctx) != nil {
+		return d.deleteManyReturning(ctx, opts)
+	}
+
+	session, err := d.collection.Database().Client().StartSession()
+	if err != nil {
+		return nil, err
+	}
+	defer session.EndSession(ctx)
+
+	docs, err := session.WithTransaction(ctx, func(ctx context.Context) (any, error) {
+		return d.deleteManyReturning(ctx, opts)
+	})
+	var commandErr mongo.CommandError
+	if errors.As(err, &commandErr) && commandErr.Code == illegalOperationCode {
+		return d.deleteManyReturning(ctx, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return docs.([]*T), nil
+}
+
+func (d *Deleter[T]) deleteManyReturning(ctx context.Context, opts []options.Lister[options.DeleteManyOptions]) ([]*T, error) {
+	currentTime := time.Now()
+	filter := d.scopedFilter()
+	globalOpContext := operation.NewOpContext(d.collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithFilter(filter), operation.WithMongoOptions(opts), operation.WithModelHook(d.modelHook), operation.WithFields(d.fields), operation.WithStartTime(currentTime))
+	opContext := NewOpContext(d.collection, filter, WithMongoOptions(opts), WithModelHook(d.modelHook), WithFields(d.fields), WithStartTime(currentTime))
+	err := d.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeDelete)
+	if err != nil {
+		return nil, err
+	}
+	// the callbacks may narrow the filter, such as the ones scoping the documents by the tenant
+	filter = globalOpContext.Filter
+
+	cursor, err := d.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	raws := make([]bson.Raw, 0)
+	if err = cursor.All(ctx, &raws); err != nil {
+		return nil, err
+	}
+	docs, ids := make([]*T, 0, len(raws)), make(bson.A, 0, len(raws))
+	for _, raw := range raws {
+		t := new(T)
+		if err = bson.Unmarshal(raw, t); err != nil {
+			return nil, err
+		}
+		docs = append(docs, t)
+		ids = append(ids, raw.Lookup("_id"))
+	}
+	globalOpContext.Doc = docs
+	opContext.Docs = docs
+	// the before delete hooks of the documents can only be executed once they are found,
+	// the model hook set by ModelHook has been executed with the callbacks
+	if d.modelHook == nil {
+		if err = model.Execute(ctx, globalOpContext, operation.OpTypeBeforeDelete); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &mongo.DeleteResult{}
+	if len(docs) > 0 {
+		// the filter is kept, so that the documents which no longer match it since they were found aren't deleted
+		filter = bson.D{{Key: "$and", Value: bson.A{filter, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}}}}}
+		if fd := d.softDeleteField(); fd != nil {
+			result, err = d.softDeleteMany(ctx, filter, fd, currentTime, opts...)
+		} else {
+			result, err = d.collection.DeleteMany(ctx, filter, opts...)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	globalOpContext.Result = result
+	opContext.Result = result
+	err = d.PostActionHandler(ctx, globalOpContext, opContext, operation.OpTypeAfterDelete)
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
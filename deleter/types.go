@@ -37,6 +37,9 @@ type OpContext struct {
 
 	Fields []*field.Filed
 
+	// Docs are the documents deleted by DeleteManyReturning, which are nil for the other deletions
+	Docs any
+
 	// result of the collection operation
 	Result any
 }
@@ -73,6 +76,12 @@ func WithModelHook(modelHook any) OpContextOption {
 	}
 }
 
+func WithDocs(docs any) OpContextOption {
+	return func(opContext *OpContext) {
+		opContext.Docs = docs
+	}
+}
+
 func WithStartTime(startTime time.Time) OpContextOption {
 	return func(opContext *OpContext) {
 		opContext.StartTime = startTime
@@ -15,6 +15,7 @@
 package field
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 	"time"
@@ -23,11 +24,20 @@ import (
 type Filed struct {
 	Name string
 	// the field name in mongo
-	MongoField     string
+	MongoField string
+	// Untagged reports whether the bson tag doesn't name the field, in which case MongoField is the Go name
+	// and the driver stores the field under the lower-cased Go name
+	Untagged       bool
 	AutoID         bool
 	FieldType      reflect.Type
 	AutoCreateTime TimeType
 	AutoUpdateTime TimeType
+	// SoftDelete marks the field that records when the document was soft deleted
+	SoftDelete TimeType
+	// Index is the index declared on the field, nil if the field isn't indexed
+	Index *Index
+	// Version marks the field that counts the modifications of the document for optimistic concurrency
+	Version bool
 
 	InlinedFields []*Filed
 }
@@ -37,6 +47,20 @@ type (
 	TimeType int64
 )
 
+// Index describes the index declared by the mongox tag of a field
+type Index struct {
+	// Name of the index, the name is generated by the driver if it is empty
+	Name   string
+	Unique bool
+	// Compound makes the fields declaring the same index name share one compound index,
+	// the keys are in the order of the fields
+	Compound bool
+	// TTL of the documents, it can only be declared on a single field index
+	TTL time.Duration
+	// Err is the error of parsing the index tags, such as an invalid ttl, which is reported when the index models are built
+	Err error
+}
+
 // Mongox time types
 const (
 	UnixTime        TimeType = 1
@@ -48,8 +72,15 @@ const (
 const (
 	CreatedAt      = "CreatedAt"
 	UpdatedAt      = "UpdatedAt"
+	DeletedAt      = "DeletedAt"
 	AutoCreateTime = "autoCreateTime"
 	AutoUpdateTime = "autoUpdateTime"
+	SoftDelete     = "softDelete"
+	IndexTag       = "index"
+	UniqueTag      = "unique"
+	CompoundTag    = "compound"
+	TTLTag         = "ttl"
+	VersionTag     = "version"
 )
 
 var (
@@ -82,19 +113,11 @@ func ParseFields[T any](doc T) []*Filed {
 			}
 		}
 
-		fd.MongoField = getMongoField(bsonTag, structField.Name)
-
-		tag := structField.Tag.Get("mongox")
-		if len(tag) > 0 {
-			parseTag(tag, fd)
-		} else if structField.Name == CreatedAt {
-			parseDefaultTimeType(structField, fd, func(timeType TimeType) {
-				fd.AutoCreateTime = timeType
-			})
-		} else if structField.Name == UpdatedAt {
-			parseDefaultTimeType(structField, fd, func(timeType TimeType) {
-				fd.AutoUpdateTime = timeType
-			})
+		fd.MongoField, fd.Untagged = getMongoField(bsonTag, structField.Name)
+
+		// the index tags don't disable the default time fields
+		if tag := structField.Tag.Get("mongox"); len(tag) == 0 || !parseTag(tag, fd) {
+			parseDefaultTag(structField, fd)
 		}
 
 		fields = append(fields, fd)
@@ -103,6 +126,23 @@ func ParseFields[T any](doc T) []*Filed {
 	return fields
 }
 
+func parseDefaultTag(structField reflect.StructField, fd *Filed) {
+	switch structField.Name {
+	case CreatedAt:
+		parseDefaultTimeType(structField, fd, func(timeType TimeType) {
+			fd.AutoCreateTime = timeType
+		})
+	case UpdatedAt:
+		parseDefaultTimeType(structField, fd, func(timeType TimeType) {
+			fd.AutoUpdateTime = timeType
+		})
+	case DeletedAt:
+		parseDefaultTimeType(structField, fd, func(timeType TimeType) {
+			fd.SoftDelete = timeType
+		})
+	}
+}
+
 func parseDefaultTimeType(structField reflect.StructField, fd *Filed, set func(timeType TimeType)) {
 	switch structField.Type.Kind() {
 	case reflect.Struct:
@@ -115,29 +155,82 @@ func parseDefaultTimeType(structField reflect.StructField, fd *Filed, set func(t
 	}
 }
 
-func getMongoField(bsonTag string, defaultValue string) string {
+// getMongoField returns the name of the bson tag, or defaultValue and true if the tag doesn't name the field
+func getMongoField(bsonTag string, defaultValue string) (string, bool) {
 	if bsonTag == "" {
-		return defaultValue
+		return defaultValue, true
 	}
 	split := strings.Split(bsonTag, ",")
 	if split[0] == "" {
-		return defaultValue
+		return defaultValue, true
 	}
-	return split[0]
+	return split[0], false
 }
 
-func parseTag(tag string, fd *Filed) {
+// parseTag parses the mongox tag into fd and reports whether the tag contains anything other than the index tags
+func parseTag(tag string, fd *Filed) bool {
+	nonIndex := false
 	split := strings.Split(tag, ",")
 	for _, s := range split {
 		switch {
 		case s == "autoID":
+			nonIndex = true
 			fd.AutoID = true
 		case strings.HasPrefix(s, AutoCreateTime):
+			nonIndex = true
 			fd.AutoCreateTime = parseTimeType(s)
 		case strings.HasPrefix(s, AutoUpdateTime):
+			nonIndex = true
 			fd.AutoUpdateTime = parseTimeType(s)
+		case strings.HasPrefix(s, SoftDelete):
+			nonIndex = true
+			// a time.Time field always stores the deletion time as a date
+			if fd.FieldType == timeType {
+				fd.SoftDelete = UnixTime
+			} else {
+				fd.SoftDelete = parseTimeType(s)
+			}
+		case s == VersionTag:
+			nonIndex = true
+			fd.Version = true
+		case s == IndexTag || strings.HasPrefix(s, IndexTag+":"):
+			index(fd).Name = tagValue(s)
+		case s == UniqueTag || strings.HasPrefix(s, UniqueTag+":"):
+			idx := index(fd)
+			idx.Unique = true
+			if name := tagValue(s); name != "" {
+				idx.Name = name
+			}
+		case s == CompoundTag:
+			index(fd).Compound = true
+		case strings.HasPrefix(s, TTLTag+":"):
+			idx := index(fd)
+			if ttl, err := time.ParseDuration(tagValue(s)); err == nil {
+				idx.TTL = ttl
+			} else {
+				idx.Err = fmt.Errorf("mongox: invalid ttl of %s: %w", fd.Name, err)
+			}
+		default:
+			nonIndex = true
 		}
 	}
+	return nonIndex
+}
+
+// index returns the index of the field, it is created if the field doesn't have one
+func index(fd *Filed) *Index {
+	if fd.Index == nil {
+		fd.Index = &Index{}
+	}
+	return fd.Index
+}
+
+// tagValue returns the value after the colon of the tag, empty if there is none
+func tagValue(tag string) string {
+	if _, value, ok := strings.Cut(tag, ":"); ok {
+		return value
+	}
+	return ""
 }
 
 func parseTimeType(tag string) TimeType {
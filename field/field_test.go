@@ -15,6 +15,7 @@
 package field
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -25,6 +26,7 @@ import (
 )
 
 func TestParseFields(t *testing.T) {
+	_, invalidTTL := time.ParseDuration("1x")
 	type model struct {
 		ID        bson.ObjectID `bson:"_id,omitempty" mongox:"autoID"`
 		CreatedAt time.Time     `bson:"created_at"`
@@ -99,6 +101,7 @@ func TestParseFields(t *testing.T) {
 					Name:       "DeletedAt",
 					MongoField: "deleted_at",
 					FieldType:  reflect.TypeOf(time.Time{}),
+					SoftDelete: UnixTime,
 				},
 				{
 					Name:           "CreateSecondTime",
@@ -139,11 +142,13 @@ func TestParseFields(t *testing.T) {
 				{
 					Name:       "NoneBsonTagField",
 					MongoField: "NoneBsonTagField",
+					Untagged:   true,
 					FieldType:  reflect.TypeOf(""),
 				},
 				{
 					Name:       "InvalidBsonTagField",
 					MongoField: "InvalidBsonTagField",
+					Untagged:   true,
 					FieldType:  reflect.TypeOf(""),
 				},
 				{
@@ -193,6 +198,7 @@ func TestParseFields(t *testing.T) {
 							Name:       "DeletedAt",
 							MongoField: "deleted_at",
 							FieldType:  reflect.TypeOf(time.Time{}),
+							SoftDelete: UnixTime,
 						},
 					},
 				},
@@ -302,6 +308,126 @@ func TestParseFields(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "soft delete tag",
+			doc: struct {
+				DeletedAt     int64     `bson:"deleted_at"`
+				RemovedAt     time.Time `bson:"removed_at" mongox:"softDelete"`
+				RemovedSecond int64     `bson:"removed_second" mongox:"softDelete"`
+				RemovedMilli  int64     `bson:"removed_milli" mongox:"softDelete:milli"`
+			}{},
+			want: []*Filed{
+				{
+					Name:       "DeletedAt",
+					MongoField: "deleted_at",
+					FieldType:  reflect.TypeOf(int64(0)),
+					SoftDelete: UnixSecond,
+				},
+				{
+					Name:       "RemovedAt",
+					MongoField: "removed_at",
+					FieldType:  reflect.TypeOf(time.Time{}),
+					SoftDelete: UnixTime,
+				},
+				{
+					Name:       "RemovedSecond",
+					MongoField: "removed_second",
+					FieldType:  reflect.TypeOf(int64(0)),
+					SoftDelete: UnixSecond,
+				},
+				{
+					Name:       "RemovedMilli",
+					MongoField: "removed_milli",
+					FieldType:  reflect.TypeOf(int64(0)),
+					SoftDelete: UnixMillisecond,
+				},
+			},
+		},
+		{
+			name: "index tag",
+			doc: struct {
+				Name      string    `bson:"name" mongox:"index"`
+				Email     string    `bson:"email" mongox:"unique"`
+				Phone     string    `bson:"phone" mongox:"unique:uniq_phone"`
+				Age       int       `bson:"age" mongox:"index:idx_age_city,compound"`
+				City      string    `bson:"city" mongox:"index:idx_age_city,compound"`
+				ExpireAt  time.Time `bson:"expire_at" mongox:"ttl:24h"`
+				CreatedAt time.Time `bson:"created_at" mongox:"index"`
+				InvalidAt time.Time `bson:"invalid_at" mongox:"ttl:1x"`
+			}{},
+			want: []*Filed{
+				{
+					Name:       "Name",
+					MongoField: "name",
+					FieldType:  reflect.TypeOf(""),
+					Index:      &Index{},
+				},
+				{
+					Name:       "Email",
+					MongoField: "email",
+					FieldType:  reflect.TypeOf(""),
+					Index:      &Index{Unique: true},
+				},
+				{
+					Name:       "Phone",
+					MongoField: "phone",
+					FieldType:  reflect.TypeOf(""),
+					Index:      &Index{Name: "uniq_phone", Unique: true},
+				},
+				{
+					Name:       "Age",
+					MongoField: "age",
+					FieldType:  reflect.TypeOf(0),
+					Index:      &Index{Name: "idx_age_city", Compound: true},
+				},
+				{
+					Name:       "City",
+					MongoField: "city",
+					FieldType:  reflect.TypeOf(""),
+					Index:      &Index{Name: "idx_age_city", Compound: true},
+				},
+				{
+					Name:       "ExpireAt",
+					MongoField: "expire_at",
+					FieldType:  reflect.TypeOf(time.Time{}),
+					Index:      &Index{TTL: 24 * time.Hour},
+				},
+				{
+					Name:           "CreatedAt",
+					MongoField:     "created_at",
+					FieldType:      reflect.TypeOf(time.Time{}),
+					AutoCreateTime: UnixTime,
+					Index:          &Index{},
+				},
+				{
+					Name:       "InvalidAt",
+					MongoField: "invalid_at",
+					FieldType:  reflect.TypeOf(time.Time{}),
+					Index:      &Index{Err: fmt.Errorf("mongox: invalid ttl of InvalidAt: %w", invalidTTL)},
+				},
+			},
+		},
+		{
+			name: "version tag",
+			doc: struct {
+				Name    string `bson:"name"`
+				Version int64  `bson:"version" mongox:"version,index"`
+			}{},
+			want: []*Filed{
+				{
+					Name:       "Name",
+					MongoField: "name",
+					FieldType:  reflect.TypeOf(""),
+				},
+				{
+					Name:       "Version",
+					MongoField: "version",
+					FieldType:  reflect.TypeOf(int64(0)),
+					Version:    true,
+					Index:      &Index{},
+				},
+			},
+		},
 		{
 			name: "invalid type 4 default time field",
 			doc: struct {
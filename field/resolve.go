@@ -0,0 +1,114 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package field
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownField is returned when a Go field path doesn't match the fields of the model
+var ErrUnknownField = errors.New("mongox: unknown field")
+
+// ErrTypeMismatch is returned when a value doesn't match the type of the field it is compared with or stored in
+var ErrTypeMismatch = errors.New("mongox: type mismatch")
+
+// parsedFields caches the fields of the nested struct types, keyed by the reflect.Type
+var parsedFields sync.Map
+
+// Resolve returns the mongo path of the Go field path, such as "Profile.Address.City" to "profile.address.city".
+// The fields of the nested structs, pointers to structs and slices of structs are parsed on demand,
+// the array indexes and the positional operators, such as "Items.0.Name" or "Items.$[].Name", are kept as they are.
+func Resolve(fields []*Filed, goPath string) (string, error) {
+	if goPath == "" {
+		return "", fmt.Errorf("%w: empty path", ErrUnknownField)
+	}
+	segments := strings.Split(goPath, ".")
+	paths := make([]string, 0, len(segments))
+	for i, segment := range segments {
+		// an index or a positional operator refers to the elements of the previous array field
+		if i > 0 && isArrayElement(segment) {
+			paths = append(paths, segment)
+			continue
+		}
+		fd := lookup(fields, segment)
+		if fd == nil {
+			return "", fmt.Errorf("%w: %s of %s", ErrUnknownField, segment, goPath)
+		}
+		paths = append(paths, mongoPath(fd))
+		fields = nestedFields(fd.FieldType)
+	}
+	return strings.Join(paths, "."), nil
+}
+
+// ResolvePath is the same as Resolve with the fields of T
+func ResolvePath[T any](goPath string) (string, error) {
+	return Resolve(nestedFields(reflect.TypeOf((*T)(nil)).Elem()), goPath)
+}
+
+// lookup finds the exported field by its Go name, the fields of the inlined structs are looked up as well
+func lookup(fields []*Filed, name string) *Filed {
+	if !token.IsExported(name) {
+		return nil
+	}
+	for _, fd := range fields {
+		if fd.InlinedFields != nil {
+			if inlined := lookup(fd.InlinedFields, name); inlined != nil {
+				return inlined
+			}
+			continue
+		}
+		if fd.Name == name && fd.MongoField != "-" {
+			return fd
+		}
+	}
+	return nil
+}
+
+// mongoPath returns the name the driver stores the field under, the untagged fields are lower-cased
+func mongoPath(fd *Filed) string {
+	if fd.Untagged {
+		return strings.ToLower(fd.Name)
+	}
+	return fd.MongoField
+}
+
+func nestedFields(typ reflect.Type) []*Filed {
+	for typ != nil && (typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array) {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct || typ == timeType {
+		return nil
+	}
+	if fields, ok := parsedFields.Load(typ); ok {
+		return fields.([]*Filed)
+	}
+	fields := ParseFields(reflect.New(typ).Interface())
+	parsedFields.Store(typ, fields)
+	return fields
+}
+
+func isArrayElement(segment string) bool {
+	if strings.HasPrefix(segment, "$") {
+		return true
+	}
+	_, err := strconv.Atoi(segment)
+	return err == nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package field
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type resolveAddress struct {
+	City string `bson:"city"`
+}
+
+type resolveProfile struct {
+	Address   *resolveAddress  `bson:"address"`
+	Addresses []resolveAddress `bson:"addresses"`
+	Age       int
+	Bio       string `bson:",omitempty"`
+	Nickname  string `bson:"Nickname"`
+}
+
+type resolveModel struct {
+	ID              bson.ObjectID  `bson:"_id"`
+	CreatedAt       time.Time      `bson:"created_at"`
+	Profile         resolveProfile `bson:"profile"`
+	Password        string         `bson:"-"`
+	secret          string
+	resolveEmbedded `bson:",inline"`
+}
+
+type resolveEmbedded struct {
+	Nickname string `bson:"nickname"`
+}
+
+func TestResolve(t *testing.T) {
+	fields := ParseFields(resolveModel{})
+	testCases := []struct {
+		name    string
+		goPath  string
+		want    string
+		wantErr error
+	}{
+		{name: "top level", goPath: "ID", want: "_id"},
+		{name: "time", goPath: "CreatedAt", want: "created_at"},
+		{name: "nested pointer", goPath: "Profile.Address.City", want: "profile.address.city"},
+		{name: "slice", goPath: "Profile.Addresses.City", want: "profile.addresses.city"},
+		{name: "index", goPath: "Profile.Addresses.0.City", want: "profile.addresses.0.city"},
+		{name: "positional", goPath: "Profile.Addresses.$[elem].City", want: "profile.addresses.$[elem].city"},
+		{name: "inlined", goPath: "Nickname", want: "nickname"},
+		{name: "untagged", goPath: "Profile.Age", want: "profile.age"},
+		{name: "tag without name", goPath: "Profile.Bio", want: "profile.bio"},
+		{name: "tag of the go name", goPath: "Profile.Nickname", want: "profile.Nickname"},
+		{name: "empty", goPath: "", wantErr: ErrUnknownField},
+		{name: "unknown", goPath: "Name", wantErr: ErrUnknownField},
+		{name: "unknown nested", goPath: "Profile.Address.Street", wantErr: ErrUnknownField},
+		{name: "ignored", goPath: "Password", wantErr: ErrUnknownField},
+		{name: "unexported", goPath: "secret", wantErr: ErrUnknownField},
+		{name: "inside time", goPath: "CreatedAt.wall", wantErr: ErrUnknownField},
+		{name: "leading index", goPath: "0.City", wantErr: ErrUnknownField},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Resolve(fields, tc.goPath)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, got)
+
+			got, err = ResolvePath[resolveModel](tc.goPath)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
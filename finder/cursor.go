@@ -0,0 +1,414 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finder
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/chenmingyong0423/go-mongox/v2/bsonx"
+	"github.com/chenmingyong0423/go-mongox/v2/builder/query"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var (
+	ErrInvalidCursor     = errors.New("mongox: invalid cursor token")
+	ErrUnsupportedSort   = errors.New("mongox: the sort can't be used for keyset pagination, only ascending or descending keys are supported")
+	ErrNoCursorSecret    = errors.New("mongox: no cursor secret to sign the cursor tokens, set it by Finder.CursorSecret or Config.CursorSecret")
+	ErrProjectedSortKey  = errors.New("mongox: the projection can't be used for keyset pagination, it must be a document keeping the keys of the sort")
+	cursorTokenEncoding  = base64.RawURLEncoding
+	cursorTokenSeparator = "."
+)
+
+// CursorPage is a page of the documents found by PageByCursor
+type CursorPage[T any] struct {
+	Items []*T
+	// NextToken is the token of the next page, which is passed to After, it's empty if there is no next page
+	NextToken string
+	HasNext   bool
+}
+
+type cursorToken struct {
+	Keys   []string        `bson:"k"`
+	Values []bson.RawValue `bson:"v"`
+}
+
+// After makes PageByCursor find the documents after the position of the token returned by the previous page
+func (f *Finder[T]) After(token string) IFinder[T] {
+	f.after = token
+	return f
+}
+
+// CursorSecret sets the secret used to sign and verify the cursor tokens, which is required by PageByCursor.
+// It must be the same on every instance sharing the tokens, so that they remain valid across restarts and replicas.
+func (f *Finder[T]) CursorSecret(secret []byte) IFinder[T] {
+	f.cursorSecret = secret
+	return f
+}
+
+// PageByCursor finds at most size documents after the token set by After, using the keys of the sort as the position.
+// The ties are always broken on _id, which is appended to the sort if absent.
+// The filter of the finder is respected while the skip and the limit are replaced by the page.
+// The documents whose sort keys are null or missing are placed as the server sorts them, before the others in ascending order.
+// The projection of the finder is applied with the sort keys kept, which are the position of the next page,
+// ErrProjectedSortKey is returned if they can't be kept, such as when a parent document of a sort key is excluded.
+// ErrNoCursorSecret is returned if no secret is set by CursorSecret or Config.CursorSecret.
+func (f *Finder[T]) PageByCursor(ctx context.Context, size int64) (*CursorPage[T], error) {
+	if size < 1 {
+		return nil, ErrInvalidPage
+	}
+	if len(f.cursorSecret) == 0 {
+		return nil, ErrNoCursorSecret
+	}
+	sort, err := cursorSort(f.sort)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := f.scopedFilter()
+	if f.after != "" {
+		values, err := f.decodeCursor(f.after, sort)
+		if err != nil {
+			return nil, err
+		}
+		filter = andFilter(filter, cursorPredicate(sort, values))
+	}
+
+	opts := options.Find().SetSort(sort).SetLimit(size + 1)
+	if f.projection != nil {
+		projection, err := cursorProjection(f.projection, sort)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetProjection(projection)
+	}
+
+	items, err := f.find(ctx, filter, []options.Lister[options.FindOptions]{opts})
+	if err != nil {
+		return nil, err
+	}
+	page := &CursorPage[T]{Items: items}
+	if int64(len(items)) > size {
+		page.Items, page.HasNext = items[:size], true
+		page.NextToken, err = f.encodeCursor(sort, page.Items[size-1])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// cursorSort normalizes the sort into keys with the direction 1 or -1, and appends _id to it if absent
+func cursorSort(sort any) (bson.D, error) {
+	var d bson.D
+	switch s := sort.(type) {
+	case nil:
+	case bson.D:
+		d = s
+	case bson.E:
+		d = bson.D{s}
+	case string:
+		d = bsonx.StringSortToBsonD(s)
+	case []string:
+		d = bsonx.StringSortToBsonD(s...)
+	case bson.M:
+		if len(s) > 1 {
+			return nil, ErrUnsupportedSort
+		}
+		for k, v := range s {
+			d = bson.D{{Key: k, Value: v}}
+		}
+	case map[string]any:
+		if len(s) > 1 {
+			return nil, ErrUnsupportedSort
+		}
+		for k, v := range s {
+			d = bson.D{{Key: k, Value: v}}
+		}
+	default:
+		return nil, ErrUnsupportedSort
+	}
+
+	res := make(bson.D, 0, len(d)+1)
+	hasID := false
+	for _, e := range d {
+		direction, ok := sortDirection(e.Value)
+		if !ok {
+			return nil, ErrUnsupportedSort
+		}
+		res = append(res, bson.E{Key: e.Key, Value: direction})
+		if e.Key == "_id" {
+			hasID = true
+		}
+	}
+	if !hasID {
+		res = append(res, bson.E{Key: "_id", Value: 1})
+	}
+	return res, nil
+}
+
+func sortDirection(v any) (int, bool) {
+	var n float64
+	switch d := v.(type) {
+	case int:
+		n = float64(d)
+	case int32:
+		n = float64(d)
+	case int64:
+		n = float64(d)
+	case float64:
+		n = d
+	default:
+		return 0, false
+	}
+	switch n {
+	case 1:
+		return 1, true
+	case -1:
+		return -1, true
+	}
+	return 0, false
+}
+
+func sortKeys(sort bson.D) []string {
+	keys := make([]string, 0, len(sort))
+	for _, e := range sort {
+		keys = append(keys, fmt.Sprintf("%s:%d", e.Key, e.Value))
+	}
+	return keys
+}
+
+// cursorPredicate builds the filter matching the documents after the values in the order of the sort, such as
+// {$or: [{a: {$gt: va}}, {a: va, b: {$gt: vb}}, {a: va, b: vb, _id: {$gt: vid}}]}.
+// The null values sort before the others, which $gt and $lt never match, so they are compared by $ne and $eq instead.
+func cursorPredicate(sort bson.D, values []bson.RawValue) bson.D {
+	conditions := make([]any, 0, len(sort))
+	for i, e := range sort {
+		isNull := values[i].Type == bson.TypeNull
+		// nothing sorts after null in descending order
+		if isNull && e.Value == -1 {
+			continue
+		}
+		condition := make(bson.D, 0, i+1)
+		for j := 0; j < i; j++ {
+			condition = append(condition, bson.E{Key: sort[j].Key, Value: values[j]})
+		}
+		switch {
+		case isNull:
+			condition = append(condition, query.Ne(e.Key, nil)...)
+		case e.Value == -1:
+			condition = append(condition, query.Or(query.Lt(e.Key, values[i]), query.Eq(e.Key, nil))...)
+		default:
+			condition = append(condition, query.Gt(e.Key, values[i])...)
+		}
+		conditions = append(conditions, condition)
+	}
+	return query.Or(conditions...)
+}
+
+// cursorProjection returns the projection which keeps the sort keys, they are included in an inclusion projection
+// and no longer excluded in an exclusion one
+func cursorProjection(projection any, sort bson.D) (bson.D, error) {
+	var d bson.D
+	switch p := projection.(type) {
+	case bson.D:
+		d = p
+	case bson.M:
+		d = sortedProjection(p)
+	case map[string]any:
+		d = sortedProjection(p)
+	default:
+		return nil, ErrProjectedSortKey
+	}
+
+	inclusion := false
+	for _, e := range d {
+		if e.Key != "_id" && projected(e.Value) {
+			inclusion = true
+		}
+	}
+	res := make(bson.D, 0, len(d)+len(sort))
+	for _, e := range d {
+		if included, ok := projectionFlag(e.Value); ok && !included {
+			excludedKey, err := excludedSortKey(e.Key, sort)
+			if err != nil {
+				return nil, err
+			}
+			// the exclusion of the sort key is dropped
+			if excludedKey {
+				continue
+			}
+		}
+		res = append(res, e)
+	}
+	if !inclusion {
+		return res, nil
+	}
+	for _, s := range sort {
+		// _id is included unless it's excluded, which has been dropped
+		if s.Key == "_id" {
+			continue
+		}
+		covered := false
+		for _, e := range d {
+			if !projected(e.Value) {
+				continue
+			}
+			if e.Key == s.Key || strings.HasPrefix(s.Key, e.Key+".") {
+				covered = true
+				break
+			}
+			if strings.HasPrefix(e.Key, s.Key+".") {
+				return nil, ErrProjectedSortKey
+			}
+		}
+		if !covered {
+			res = append(res, bson.E{Key: s.Key, Value: 1})
+		}
+	}
+	return res, nil
+}
+
+// excludedSortKey reports whether the key excluded by the projection is a sort key,
+// an error is returned if it's the parent document of a sort key
+func excludedSortKey(key string, sort bson.D) (bool, error) {
+	for _, e := range sort {
+		if e.Key == key {
+			return true, nil
+		}
+		if strings.HasPrefix(e.Key, key+".") {
+			return false, ErrProjectedSortKey
+		}
+	}
+	return false, nil
+}
+
+// projectionFlag returns the value of the field projected by 1/true or 0/false as whether it's included
+func projectionFlag(v any) (bool, bool) {
+	switch b := v.(type) {
+	case bool:
+		return b, true
+	case int:
+		return b != 0, true
+	case int32:
+		return b != 0, true
+	case int64:
+		return b != 0, true
+	case float64:
+		return b != 0, true
+	}
+	return false, false
+}
+
+// projected reports whether the field is included by the projection
+func projected(v any) bool {
+	included, ok := projectionFlag(v)
+	return ok && included
+}
+
+func sortedProjection(m map[string]any) bson.D {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	d := make(bson.D, 0, len(keys))
+	for _, k := range keys {
+		d = append(d, bson.E{Key: k, Value: m[k]})
+	}
+	return d
+}
+
+func andFilter(filter any, predicate bson.D) any {
+	switch f := filter.(type) {
+	case nil:
+		return predicate
+	case bson.D:
+		if len(f) == 0 {
+			return predicate
+		}
+	case bson.M:
+		if len(f) == 0 {
+			return predicate
+		}
+	}
+	return query.And(filter, predicate)
+}
+
+func (f *Finder[T]) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, f.cursorSecret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// encodeCursor encodes the values of the sort keys of the doc into a signed token
+func (f *Finder[T]) encodeCursor(sort bson.D, doc *T) (string, error) {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	values := make([]bson.RawValue, 0, len(sort))
+	for _, e := range sort {
+		value, err := bson.Raw(raw).LookupErr(strings.Split(e.Key, ".")...)
+		if err != nil {
+			value = bson.RawValue{Type: bson.TypeNull}
+		}
+		values = append(values, value)
+	}
+	payload, err := bson.Marshal(cursorToken{Keys: sortKeys(sort), Values: values})
+	if err != nil {
+		return "", err
+	}
+	return cursorTokenEncoding.EncodeToString(payload) + cursorTokenSeparator + cursorTokenEncoding.EncodeToString(f.sign(payload)), nil
+}
+
+// decodeCursor verifies the token and returns the values of the sort keys in it
+func (f *Finder[T]) decodeCursor(token string, sort bson.D) ([]bson.RawValue, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, cursorTokenSeparator)
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	payload, err := cursorTokenEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	signature, err := cursorTokenEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, f.sign(payload)) {
+		return nil, ErrInvalidCursor
+	}
+
+	var ct cursorToken
+	if err = bson.Unmarshal(payload, &ct); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	keys := sortKeys(sort)
+	if len(ct.Keys) != len(keys) || len(ct.Values) != len(keys) {
+		return nil, ErrInvalidCursor
+	}
+	for i := range keys {
+		if ct.Keys[i] != keys[i] {
+			return nil, ErrInvalidCursor
+		}
+	}
+	return ct.Values, nil
+}
@@ -0,0 +1,239 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finder
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type cursorUser struct {
+	ID   bson.ObjectID `bson:"_id"`
+	Name string        `bson:"name"`
+	Age  int64         `bson:"age"`
+}
+
+func Test_cursorSort(t *testing.T) {
+	testCases := []struct {
+		name    string
+		sort    any
+		want    bson.D
+		wantErr error
+	}{
+		{
+			name: "nil",
+			want: bson.D{{Key: "_id", Value: 1}},
+		},
+		{
+			name: "bson.D",
+			sort: bson.D{{Key: "age", Value: -1}, {Key: "name", Value: int32(1)}},
+			want: bson.D{{Key: "age", Value: -1}, {Key: "name", Value: 1}, {Key: "_id", Value: 1}},
+		},
+		{
+			name: "string",
+			sort: "-age",
+			want: bson.D{{Key: "age", Value: -1}, {Key: "_id", Value: 1}},
+		},
+		{
+			name: "strings with _id",
+			sort: []string{"age", "-_id"},
+			want: bson.D{{Key: "age", Value: 1}, {Key: "_id", Value: -1}},
+		},
+		{
+			name: "single key map",
+			sort: bson.M{"age": int64(-1)},
+			want: bson.D{{Key: "age", Value: -1}, {Key: "_id", Value: 1}},
+		},
+		{
+			name:    "multiple keys map",
+			sort:    bson.M{"age": 1, "name": 1},
+			wantErr: ErrUnsupportedSort,
+		},
+		{
+			name:    "text score",
+			sort:    bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}},
+			wantErr: ErrUnsupportedSort,
+		},
+		{
+			name:    "unsupported type",
+			sort:    1,
+			wantErr: ErrUnsupportedSort,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := cursorSort(tc.sort)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func rawValue(t *testing.T, v any) bson.RawValue {
+	raw, err := bson.Marshal(bson.D{{Key: "v", Value: v}})
+	require.NoError(t, err)
+	return bson.Raw(raw).Lookup("v")
+}
+
+func Test_cursorPredicate(t *testing.T) {
+	age, name := rawValue(t, int64(18)), rawValue(t, "cmy")
+	sort := bson.D{{Key: "age", Value: -1}, {Key: "name", Value: 1}}
+	// the null values sort after the others in descending order
+	assert.Equal(t, bson.D{{Key: "$or", Value: []any{
+		bson.D{{Key: "$or", Value: []any{
+			bson.D{{Key: "age", Value: bson.D{{Key: "$lt", Value: age}}}},
+			bson.D{{Key: "age", Value: bson.D{{Key: "$eq", Value: nil}}}},
+		}}},
+		bson.D{{Key: "age", Value: age}, {Key: "name", Value: bson.D{{Key: "$gt", Value: name}}}},
+	}}}, cursorPredicate(sort, []bson.RawValue{age, name}))
+}
+
+func Test_cursorPredicate_Null(t *testing.T) {
+	null, name := bson.RawValue{Type: bson.TypeNull}, rawValue(t, "cmy")
+
+	// nothing sorts after null in descending order
+	sort := bson.D{{Key: "age", Value: -1}, {Key: "name", Value: 1}}
+	assert.Equal(t, bson.D{{Key: "$or", Value: []any{
+		bson.D{{Key: "age", Value: null}, {Key: "name", Value: bson.D{{Key: "$gt", Value: name}}}},
+	}}}, cursorPredicate(sort, []bson.RawValue{null, name}))
+
+	// everything but null sorts after null in ascending order
+	sort = bson.D{{Key: "age", Value: 1}, {Key: "name", Value: -1}}
+	assert.Equal(t, bson.D{{Key: "$or", Value: []any{
+		bson.D{{Key: "age", Value: bson.D{{Key: "$ne", Value: nil}}}},
+		bson.D{{Key: "age", Value: null}, {Key: "$or", Value: []any{
+			bson.D{{Key: "name", Value: bson.D{{Key: "$lt", Value: name}}}},
+			bson.D{{Key: "name", Value: bson.D{{Key: "$eq", Value: nil}}}},
+		}}},
+	}}}, cursorPredicate(sort, []bson.RawValue{null, name}))
+}
+
+func Test_andFilter(t *testing.T) {
+	predicate := bson.D{{Key: "age", Value: 18}}
+	assert.Equal(t, predicate, andFilter(nil, predicate))
+	assert.Equal(t, predicate, andFilter(bson.D{}, predicate))
+	assert.Equal(t, predicate, andFilter(bson.M{}, predicate))
+	assert.Equal(t, bson.D{{Key: "$and", Value: []any{bson.M{"name": "cmy"}, predicate}}}, andFilter(bson.M{"name": "cmy"}, predicate))
+}
+
+func TestFinder_cursorToken(t *testing.T) {
+	f := NewFinder[cursorUser](&mongo.Collection{}, nil, nil).CursorSecret([]byte("cursor secret")).(*Finder[cursorUser])
+	sort, err := cursorSort("-age")
+	require.NoError(t, err)
+
+	user := &cursorUser{ID: bson.NewObjectID(), Name: "cmy", Age: 18}
+	token, err := f.encodeCursor(sort, user)
+	require.NoError(t, err)
+
+	values, err := f.decodeCursor(token, sort)
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+	assert.Equal(t, int64(18), values[0].Int64())
+	assert.Equal(t, user.ID, values[1].ObjectID())
+
+	t.Run("tampered payload", func(t *testing.T) {
+		payload, signature, _ := strings.Cut(token, cursorTokenSeparator)
+		raw, err := cursorTokenEncoding.DecodeString(payload)
+		require.NoError(t, err)
+		raw[len(raw)-2] ^= 1
+		_, err = f.decodeCursor(cursorTokenEncoding.EncodeToString(raw)+cursorTokenSeparator+signature, sort)
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+	t.Run("another secret", func(t *testing.T) {
+		_, err := NewFinder[cursorUser](&mongo.Collection{}, nil, nil).CursorSecret([]byte("secret")).(*Finder[cursorUser]).decodeCursor(token, sort)
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+	t.Run("another sort", func(t *testing.T) {
+		another, err := cursorSort("age")
+		require.NoError(t, err)
+		_, err = f.decodeCursor(token, another)
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		_, err := f.decodeCursor("token", sort)
+		assert.Equal(t, ErrInvalidCursor, err)
+		_, err = f.decodeCursor("!."+strings.SplitN(token, ".", 2)[1], sort)
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+}
+
+func Test_cursorProjection(t *testing.T) {
+	sort := bson.D{{Key: "age", Value: -1}, {Key: "profile.score", Value: 1}, {Key: "_id", Value: 1}}
+	testCases := []struct {
+		name       string
+		projection any
+		want       bson.D
+		wantErr    error
+	}{
+		{
+			name:       "inclusion",
+			projection: bson.D{{Key: "name", Value: 1}},
+			want:       bson.D{{Key: "name", Value: 1}, {Key: "age", Value: 1}, {Key: "profile.score", Value: 1}},
+		},
+		{
+			name:       "inclusion of the sort keys",
+			projection: bson.M{"age": true, "profile": 1, "_id": 0},
+			want:       bson.D{{Key: "age", Value: true}, {Key: "profile", Value: 1}},
+		},
+		{
+			name:       "exclusion",
+			projection: bson.D{{Key: "age", Value: 0}, {Key: "password", Value: 0}, {Key: "_id", Value: false}},
+			want:       bson.D{{Key: "password", Value: 0}},
+		},
+		{
+			name:       "exclusion of the parent of a sort key",
+			projection: map[string]any{"profile": 0},
+			wantErr:    ErrProjectedSortKey,
+		},
+		{
+			name:       "inclusion of the children of a sort key",
+			projection: bson.D{{Key: "age.value", Value: 1}},
+			wantErr:    ErrProjectedSortKey,
+		},
+		{
+			name:       "not a document",
+			projection: "name",
+			wantErr:    ErrProjectedSortKey,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := cursorProjection(tc.projection, sort)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestFinder_PageByCursor_Invalid(t *testing.T) {
+	_, err := NewFinder[cursorUser](&mongo.Collection{}, nil, nil).PageByCursor(context.Background(), 10)
+	assert.Equal(t, ErrNoCursorSecret, err)
+
+	f := NewFinder[cursorUser](&mongo.Collection{}, nil, nil).CursorSecret([]byte("secret"))
+	_, err = f.PageByCursor(context.Background(), 0)
+	assert.Equal(t, ErrInvalidPage, err)
+	_, err = f.Sort(1).PageByCursor(context.Background(), 10)
+	assert.Equal(t, ErrUnsupportedSort, err)
+	_, err = f.Sort("-age").After("token").PageByCursor(context.Background(), 10)
+	assert.Equal(t, ErrInvalidCursor, err)
+	_, err = f.Sort("-age").After("").Projection(bson.M{"age.value": 1}).PageByCursor(context.Background(), 10)
+	assert.Equal(t, ErrProjectedSortKey, err)
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finder
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// ErrUnsupportedFinder is returned by FindAs and FindOneAs when the IFinder isn't a *Finder, such as a mock
+var ErrUnsupportedFinder = errors.New("mongox: FindAs and FindOneAs only support *Finder")
+
+// FindAs finds the documents with the filter, sort, skip and limit of the finder and decodes them into D.
+// The projection is derived from the bson tags of D, unless it is set by the options or the Projection of the finder.
+// The find callbacks are executed with the documents of type D, while the after hooks of the finder don't receive them.
+func FindAs[T any, D any](ctx context.Context, finder IFinder[T], opts ...options.Lister[options.FindOptions]) ([]*D, error) {
+	f, ok := finder.(*Finder[T])
+	if !ok {
+		return nil, ErrUnsupportedFinder
+	}
+	if projection := projectionOf[D](); projection != nil {
+		opts = append([]options.Lister[options.FindOptions]{options.Find().SetProjection(projection)}, opts...)
+	}
+	return find[T, D](ctx, f, f.scopedFilter(), f.findOptions(opts))
+}
+
+// FindOneAs finds one document with the filter and sort of the finder and decodes it into D,
+// the projection is derived from the bson tags of D like FindAs
+func FindOneAs[T any, D any](ctx context.Context, finder IFinder[T], opts ...options.Lister[options.FindOneOptions]) (*D, error) {
+	f, ok := finder.(*Finder[T])
+	if !ok {
+		return nil, ErrUnsupportedFinder
+	}
+	if projection := projectionOf[D](); projection != nil {
+		opts = append([]options.Lister[options.FindOneOptions]{options.FindOne().SetProjection(projection)}, opts...)
+	}
+	return findOne[T, D](ctx, f, opts)
+}
+
+// projectionOf returns the projection including the fields of D, which are named the same way as the driver does.
+// It returns nil if D isn't a struct or has an inlined map, whose fields can't be known in advance.
+func projectionOf[D any]() bson.D {
+	docType := reflect.TypeOf((*D)(nil)).Elem()
+	if docType.Kind() != reflect.Struct {
+		return nil
+	}
+	projection, ok := appendProjection(bson.D{}, docType)
+	if !ok || len(projection) == 0 {
+		return nil
+	}
+	return projection
+}
+
+func appendProjection(projection bson.D, docType reflect.Type) (bson.D, bool) {
+	for i := 0; i < docType.NumField(); i++ {
+		structField := docType.Field(i)
+		if !structField.IsExported() && !structField.Anonymous {
+			continue
+		}
+		key, flags, _ := strings.Cut(structField.Tag.Get("bson"), ",")
+		if key == "-" {
+			continue
+		}
+		if strings.Contains(","+flags+",", ",inline,") {
+			fieldType := structField.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() != reflect.Struct {
+				return nil, false
+			}
+			var ok bool
+			if projection, ok = appendProjection(projection, fieldType); !ok {
+				return nil, false
+			}
+			continue
+		}
+		if !structField.IsExported() {
+			continue
+		}
+		if key == "" {
+			key = strings.ToLower(structField.Name)
+		}
+		projection = append(projection, bson.E{Key: key, Value: 1})
+	}
+	return projection, true
+}
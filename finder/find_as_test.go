@@ -0,0 +1,62 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type auditView struct {
+	CreatedAt time.Time `bson:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at,omitempty"`
+}
+
+type userView struct {
+	ID        bson.ObjectID `bson:"_id"`
+	Name      string        `bson:"name"`
+	Age       int64
+	Password  string `bson:"-"`
+	internal  string
+	auditView `bson:",inline"`
+}
+
+func Test_projectionOf(t *testing.T) {
+	assert.Equal(t, bson.D{
+		{Key: "_id", Value: 1},
+		{Key: "name", Value: 1},
+		{Key: "age", Value: 1},
+		{Key: "created_at", Value: 1},
+		{Key: "updated_at", Value: 1},
+	}, projectionOf[userView]())
+
+	assert.Nil(t, projectionOf[bson.M]())
+	assert.Nil(t, projectionOf[struct {
+		Name  string         `bson:"name"`
+		Extra map[string]any `bson:",inline"`
+	}]())
+	assert.Nil(t, projectionOf[struct{ internal string }]())
+}
+
+func TestFindAs_UnsupportedFinder(t *testing.T) {
+	_, err := FindAs[cursorUser, userView](context.Background(), nil)
+	assert.Equal(t, ErrUnsupportedFinder, err)
+	_, err = FindOneAs[cursorUser, userView](context.Background(), nil)
+	assert.Equal(t, ErrUnsupportedFinder, err)
+}
@@ -16,10 +16,15 @@ package finder
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/chenmingyong0423/go-mongox/v2/bsonx"
 	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/replace"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/softdelete"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/version"
 
 	"github.com/chenmingyong0423/go-mongox/v2/callback"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -34,18 +39,33 @@ import (
 type IFinder[T any] interface {
 	FindOne(ctx context.Context, opts ...options.Lister[options.FindOneOptions]) (*T, error)
 	Find(ctx context.Context, opts ...options.Lister[options.FindOptions]) ([]*T, error)
+	Iter(ctx context.Context, opts ...options.Lister[options.FindOptions]) (*Iterator[T], error)
+	FindInBatches(ctx context.Context, size int, fn func(docs []*T) error, opts ...options.Lister[options.FindOptions]) error
+	Paginate(ctx context.Context, page, pageSize int64) (*Page[T], error)
+	FacetPagination() IFinder[T]
+	PageByCursor(ctx context.Context, size int64) (*CursorPage[T], error)
+	After(token string) IFinder[T]
+	CursorSecret(secret []byte) IFinder[T]
 	Count(ctx context.Context, opts ...options.Lister[options.CountOptions]) (int64, error)
+	EstimatedCount(ctx context.Context, opts ...options.Lister[options.EstimatedDocumentCountOptions]) (int64, error)
 	Distinct(ctx context.Context, fieldName string, opts ...options.Lister[options.DistinctOptions]) *mongo.DistinctResult
 	DistinctWithParse(ctx context.Context, fieldName string, result any, opts ...options.Lister[options.DistinctOptions]) error
 	Filter(filter any) IFinder[T]
 	FindOneAndUpdate(ctx context.Context, opts ...options.Lister[options.FindOneAndUpdateOptions]) (*T, error)
+	FindOneAndDelete(ctx context.Context, opts ...options.Lister[options.FindOneAndDeleteOptions]) (*T, error)
+	FindOneAndReplace(ctx context.Context, opts ...options.Lister[options.FindOneAndReplaceOptions]) (*T, error)
 	Limit(limit int64) IFinder[T]
 	ModelHook(modelHook any) IFinder[T]
 	RegisterAfterHooks(hooks ...AfterHookFn[T]) IFinder[T]
 	RegisterBeforeHooks(hooks ...BeforeHookFn[T]) IFinder[T]
 	Skip(skip int64) IFinder[T]
 	Sort(sort any) IFinder[T]
+	Projection(projection any) IFinder[T]
+	Replacement(replacement any) IFinder[T]
 	Updates(update any) IFinder[T]
+	Unscoped() IFinder[T]
+	HardDelete() IFinder[T]
+	Version(current any) IFinder[T]
 	PostActionHandler(ctx context.Context, globalOpContext *operation.OpContext, opContext *OpContext[T], opTypes ...operation.OpType) (err error)
 	PreActionHandler(ctx context.Context, globalOpContext *operation.OpContext, opContext *OpContext[T], opTypes ...operation.OpType) (err error)
 	GetCollection() *mongo.Collection
@@ -70,6 +90,16 @@ type Finder[T any] struct {
 
 	skip, limit int64
 	sort        any
+	projection  any
+	replacement any
+	unscoped    bool
+	hardDelete  bool
+	facet       bool
+	// after is the token of the position where PageByCursor starts
+	after        string
+	cursorSecret []byte
+	// version is the current version of the document expected by FindOneAndUpdate
+	version any
 }
 
 func (f *Finder[T]) RegisterBeforeHooks(hooks ...BeforeHookFn[T]) IFinder[T] {
@@ -106,6 +136,18 @@ func (f *Finder[T]) Sort(sort any) IFinder[T] {
 	return f
 }
 
+// Projection is used to set the fields returned by FindOne, Find and the FindOneAnd* methods
+func (f *Finder[T]) Projection(projection any) IFinder[T] {
+	f.projection = projection
+	return f
+}
+
+// Replacement is used to set the replacement of FindOneAndReplace
+func (f *Finder[T]) Replacement(replacement any) IFinder[T] {
+	f.replacement = replacement
+	return f
+}
+
 func (f *Finder[T]) Updates(update any) IFinder[T] {
 	f.updates = update
 	return f
@@ -116,6 +158,59 @@ func (f *Finder[T]) ModelHook(modelHook any) IFinder[T] {
 	return f
 }
 
+// Unscoped makes the query include the soft deleted documents
+func (f *Finder[T]) Unscoped() IFinder[T] {
+	f.unscoped = true
+	return f
+}
+
+// HardDelete makes FindOneAndDelete remove the document physically even if the model has a soft delete field
+func (f *Finder[T]) HardDelete() IFinder[T] {
+	f.hardDelete = true
+	return f
+}
+
+// softDeleteField returns the soft delete field of the model, nil if FindOneAndDelete should remove the document physically
+func (f *Finder[T]) softDeleteField() *field.Filed {
+	if f.hardDelete {
+		return nil
+	}
+	return softdelete.Field(f.fields)
+}
+
+// ErrVersionConflict is returned when FindOneAndUpdate checking the version matches no document,
+// the returned error is a *VersionConflictError, so it should be checked by errors.Is
+var ErrVersionConflict = version.ErrConflict
+
+// VersionConflictError is the ErrVersionConflict carrying the expected version, it can be got by errors.As
+type VersionConflictError = version.ConflictError
+
+// Version makes FindOneAndUpdate only update the document whose version field equals current,
+// ErrVersionConflict is returned if no document matches
+func (f *Finder[T]) Version(current any) IFinder[T] {
+	f.version = current
+	return f
+}
+
+// versionedFilter adds the version condition to the filter if the model has a version field and Version is called,
+// the returned conflict is the error of a write matching no document, nil if the filter isn't versioned
+func (f *Finder[T]) versionedFilter(filter any) (any, error) {
+	if f.version == nil {
+		return filter, nil
+	}
+	fd := version.Field(f.fields)
+	if fd == nil {
+		return filter, nil
+	}
+	return version.Scope(filter, fd, f.version), &VersionConflictError{Expected: f.version}
+}
+
+// scopedFilter returns the filter sent to the collection,
+// which excludes the soft deleted documents unless Unscoped is called
+func (f *Finder[T]) scopedFilter() any {
+	return softdelete.ScopeLive(f.fields, f.FilterObj, f.unscoped)
+}
+
 func (f *Finder[T]) PreActionHandler(ctx context.Context, globalOpContext *operation.OpContext, opContext *OpContext[T], opTypes ...operation.OpType) (err error) {
 	for _, opType := range opTypes {
 		err = f.DBCallbacks.Execute(ctx, globalOpContext, opType)
@@ -149,21 +244,30 @@ func (f *Finder[T]) PostActionHandler(ctx context.Context, globalOpContext *oper
 }
 
 func (f *Finder[T]) FindOne(ctx context.Context, opts ...options.Lister[options.FindOneOptions]) (*T, error) {
+	return findOne[T, T](ctx, f, opts)
+}
+
+// findOne finds one document with the finder and decodes it into a D
+func findOne[T any, D any](ctx context.Context, f *Finder[T], opts []options.Lister[options.FindOneOptions]) (*D, error) {
 	currentTime := time.Now()
+	filter := f.scopedFilter()
 	if f.sort != nil {
 		opts = append(opts, options.FindOne().SetSort(f.sort))
 	}
+	if f.projection != nil {
+		opts = append(opts, options.FindOne().SetProjection(f.projection))
+	}
 
-	t := new(T)
+	t := new(D)
 
-	globalOpContext := operation.NewOpContext(f.Collection, operation.WithFilter(f.FilterObj), operation.WithMongoOptions(opts), operation.WithModelHook(f.modelHook), operation.WithStartTime(currentTime), operation.WithFields(f.fields))
-	opContext := NewOpContext(f.Collection, f.FilterObj, WithMongoOptions[T](opts), WithModelHook[T](f.modelHook), WithStartTime[T](currentTime), WithFields[T](f.fields))
+	globalOpContext := operation.NewOpContext(f.Collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithFilter(filter), operation.WithMongoOptions(opts), operation.WithModelHook(f.modelHook), operation.WithStartTime(currentTime), operation.WithFields(f.fields))
+	opContext := NewOpContext(f.Collection, filter, WithMongoOptions[T](opts), WithModelHook[T](f.modelHook), WithStartTime[T](currentTime), WithFields[T](f.fields))
 	err := f.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeFind)
 	if err != nil {
 		return nil, err
 	}
 
-	result := f.Collection.FindOne(ctx, f.FilterObj, opts...)
+	result := f.Collection.FindOne(ctx, filter, opts...)
 	err = result.Decode(t)
 	if err != nil {
 		return nil, err
@@ -172,7 +276,10 @@ func (f *Finder[T]) FindOne(ctx context.Context, opts ...options.Lister[options.
 	globalOpContext.Result = result
 	globalOpContext.Doc = t
 	opContext.Result = result
-	opContext.Doc = t
+	// the hooks of the finder only receive the document when it is decoded into a T
+	if doc, ok := any(t).(*T); ok {
+		opContext.Doc = doc
+	}
 	err = f.PostActionHandler(ctx, globalOpContext, opContext, operation.OpTypeAfterFind)
 	if err != nil {
 		return nil, err
@@ -182,28 +289,27 @@ func (f *Finder[T]) FindOne(ctx context.Context, opts ...options.Lister[options.
 }
 
 func (f *Finder[T]) Find(ctx context.Context, opts ...options.Lister[options.FindOptions]) ([]*T, error) {
-	currentTime := time.Now()
+	return f.find(ctx, f.scopedFilter(), f.findOptions(opts))
+}
 
-	if f.sort != nil {
-		opts = append(opts, options.Find().SetSort(f.sort))
-	}
-	if f.skip != 0 {
-		opts = append(opts, options.Find().SetSkip(f.skip))
-	}
-	if f.limit != 0 {
-		opts = append(opts, options.Find().SetLimit(f.limit))
-	}
+func (f *Finder[T]) find(ctx context.Context, filter any, opts []options.Lister[options.FindOptions]) ([]*T, error) {
+	return find[T, T](ctx, f, filter, opts)
+}
+
+// find finds the documents matching the filter with the finder and decodes them into D
+func find[T any, D any](ctx context.Context, f *Finder[T], filter any, opts []options.Lister[options.FindOptions]) ([]*D, error) {
+	currentTime := time.Now()
 
-	t := make([]*T, 0)
+	t := make([]*D, 0)
 
-	globalOpContext := operation.NewOpContext(f.Collection, operation.WithFilter(f.FilterObj), operation.WithMongoOptions(opts), operation.WithModelHook(f.modelHook), operation.WithStartTime(currentTime), operation.WithFields(f.fields))
-	opContext := NewOpContext(f.Collection, f.FilterObj, WithMongoOptions[T](opts), WithModelHook[T](f.modelHook), WithStartTime[T](currentTime), WithFields[T](f.fields))
+	globalOpContext := operation.NewOpContext(f.Collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithFilter(filter), operation.WithMongoOptions(opts), operation.WithModelHook(f.modelHook), operation.WithStartTime(currentTime), operation.WithFields(f.fields))
+	opContext := NewOpContext(f.Collection, filter, WithMongoOptions[T](opts), WithModelHook[T](f.modelHook), WithStartTime[T](currentTime), WithFields[T](f.fields))
 	err := f.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeFind)
 	if err != nil {
 		return nil, err
 	}
 
-	cursor, err := f.Collection.Find(ctx, f.FilterObj, opts...)
+	cursor, err := f.Collection.Find(ctx, filter, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -218,7 +324,9 @@ func (f *Finder[T]) Find(ctx context.Context, opts ...options.Lister[options.Fin
 	globalOpContext.Result = cursor
 	globalOpContext.Doc = t
 	opContext.Result = cursor
-	opContext.Docs = t
+	if docs, ok := any(t).([]*T); ok {
+		opContext.Docs = docs
+	}
 	err = f.PostActionHandler(ctx, globalOpContext, opContext, operation.OpTypeAfterFind)
 	if err != nil {
 		return nil, err
@@ -227,50 +335,353 @@ func (f *Finder[T]) Find(ctx context.Context, opts ...options.Lister[options.Fin
 	return t, nil
 }
 
+// findOptions appends the sort, projection, skip and limit of the finder to the options of Find
+func (f *Finder[T]) findOptions(opts []options.Lister[options.FindOptions]) []options.Lister[options.FindOptions] {
+	if f.sort != nil {
+		opts = append(opts, options.Find().SetSort(f.sort))
+	}
+	if f.projection != nil {
+		opts = append(opts, options.Find().SetProjection(f.projection))
+	}
+	if f.skip != 0 {
+		opts = append(opts, options.Find().SetSkip(f.skip))
+	}
+	if f.limit != 0 {
+		opts = append(opts, options.Find().SetLimit(f.limit))
+	}
+	return opts
+}
+
 func (f *Finder[T]) Count(ctx context.Context, opts ...options.Lister[options.CountOptions]) (int64, error) {
-	return f.Collection.CountDocuments(ctx, f.FilterObj, opts...)
+	return f.count(ctx, f.scopedFilter(), opts, func(filter any) (int64, error) {
+		return f.Collection.CountDocuments(ctx, filter, opts...)
+	})
 }
 
+// EstimatedCount returns an estimation of the number of documents in the collection from its metadata,
+// the filter is ignored, so the result includes the documents that have been soft deleted.
+// It goes through the before/after count callbacks with a nil filter.
+func (f *Finder[T]) EstimatedCount(ctx context.Context, opts ...options.Lister[options.EstimatedDocumentCountOptions]) (int64, error) {
+	return f.count(ctx, nil, opts, func(any) (int64, error) {
+		return f.Collection.EstimatedDocumentCount(ctx, opts...)
+	})
+}
+
+func (f *Finder[T]) count(ctx context.Context, filter any, opts any, fn func(filter any) (int64, error)) (int64, error) {
+	currentTime := time.Now()
+
+	globalOpContext := operation.NewOpContext(f.Collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithFilter(filter), operation.WithMongoOptions(opts), operation.WithModelHook(f.modelHook), operation.WithStartTime(currentTime), operation.WithFields(f.fields))
+	opContext := NewOpContext(f.Collection, filter, WithMongoOptions[T](opts), WithModelHook[T](f.modelHook), WithStartTime[T](currentTime), WithFields[T](f.fields))
+	err := f.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeCount)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := fn(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	globalOpContext.Result = count
+	opContext.Result = count
+	err = f.PostActionHandler(ctx, globalOpContext, opContext, operation.OpTypeAfterCount)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Distinct goes through the before/after distinct callbacks,
+// an error returned by them is reported by the Err method of the result, wrapped by the driver,
+// so it should be checked by errors.Is or errors.As, DistinctWithParse returns it as it is
 func (f *Finder[T]) Distinct(ctx context.Context, fieldName string, opts ...options.Lister[options.DistinctOptions]) *mongo.DistinctResult {
-	return f.Collection.Distinct(ctx, fieldName, f.FilterObj, opts...)
+	result, err := f.distinct(ctx, fieldName, opts)
+	if err != nil {
+		return f.Collection.Distinct(ctx, fieldName, failedFilter{err: err})
+	}
+	return result
 }
 
 // DistinctWithParse is used to parse the result of Distinct
-// result must be a pointer
+// result must be a pointer, an error returned by the callbacks is returned as it is
 func (f *Finder[T]) DistinctWithParse(ctx context.Context, fieldName string, result any, opts ...options.Lister[options.DistinctOptions]) error {
-	distinctResult := f.Collection.Distinct(ctx, fieldName, f.FilterObj, opts...)
-	if distinctResult.Err() != nil {
-		return distinctResult.Err()
+	distinctResult, err := f.distinct(ctx, fieldName, opts)
+	if err != nil {
+		return err
 	}
-	err := distinctResult.Decode(result)
+	err = distinctResult.Decode(result)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+func (f *Finder[T]) distinct(ctx context.Context, fieldName string, opts []options.Lister[options.DistinctOptions]) (*mongo.DistinctResult, error) {
+	currentTime := time.Now()
+	filter := f.scopedFilter()
+
+	globalOpContext := operation.NewOpContext(f.Collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithFilter(filter), operation.WithMongoOptions(opts), operation.WithModelHook(f.modelHook), operation.WithStartTime(currentTime), operation.WithFields(f.fields))
+	opContext := NewOpContext(f.Collection, filter, WithMongoOptions[T](opts), WithModelHook[T](f.modelHook), WithStartTime[T](currentTime), WithFields[T](f.fields))
+	err := f.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeDistinct)
+	if err != nil {
+		return nil, err
+	}
+
+	result := f.Collection.Distinct(ctx, fieldName, filter, opts...)
+	if result.Err() != nil {
+		return nil, result.Err()
+	}
+
+	globalOpContext.Result = result
+	opContext.Result = result
+	err = f.PostActionHandler(ctx, globalOpContext, opContext, operation.OpTypeAfterDistinct)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// failedFilter fails to be marshalled with the error it holds,
+// a mongo.DistinctResult can't be created with an error outside the driver,
+// so a distinct of it is used to report the error of the callbacks by the result
+type failedFilter struct {
+	err error
+}
+
+func (f failedFilter) MarshalBSON() ([]byte, error) {
+	return nil, f.err
+}
+
 func (f *Finder[T]) FindOneAndUpdate(ctx context.Context, opts ...options.Lister[options.FindOneAndUpdateOptions]) (*T, error) {
 	currentTime := time.Now()
+	filter, conflict := f.versionedFilter(f.scopedFilter())
 	t := new(T)
+	if f.sort != nil {
+		opts = append(opts, options.FindOneAndUpdate().SetSort(f.sort))
+	}
+	if f.projection != nil {
+		opts = append(opts, options.FindOneAndUpdate().SetProjection(f.projection))
+	}
 
 	updates := bsonx.ToBsonM(f.updates)
 	if len(updates) != 0 {
 		f.updates = updates
 	}
 
-	globalOpContext := operation.NewOpContext(f.Collection, operation.WithFilter(f.FilterObj), operation.WithUpdates(f.updates), operation.WithMongoOptions(opts), operation.WithModelHook(f.modelHook), operation.WithStartTime(currentTime), operation.WithFields(f.fields))
-	opContext := NewOpContext(f.Collection, f.FilterObj, WithUpdates[T](f.updates), WithMongoOptions[T](opts), WithModelHook[T](f.modelHook), WithStartTime[T](currentTime), WithFields[T](f.fields))
+	globalOpContext := operation.NewOpContext(f.Collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithFilter(filter), operation.WithUpdates(f.updates), operation.WithMongoOptions(opts), operation.WithModelHook(f.modelHook), operation.WithStartTime(currentTime), operation.WithFields(f.fields))
+	opContext := NewOpContext(f.Collection, filter, WithUpdates[T](f.updates), WithMongoOptions[T](opts), WithModelHook[T](f.modelHook), WithStartTime[T](currentTime), WithFields[T](f.fields))
+
+	err := f.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeFind, operation.OpTypeBeforeUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	result := f.Collection.FindOneAndUpdate(ctx, filter, globalOpContext.Updates, opts...)
+	err = result.Decode(t)
+	if err != nil {
+		if conflict != nil && errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, conflict
+		}
+		return nil, err
+	}
+
+	globalOpContext.Result = result
+	globalOpContext.Doc = t
+	opContext.Result = result
+	opContext.Doc = t
+	err = f.PostActionHandler(ctx, globalOpContext, opContext, operation.OpTypeAfterFind, operation.OpTypeAfterUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// FindOneAndDelete deletes the first document matching the filter in the order of the sort and returns it.
+// The beforeFind and beforeDelete callbacks are executed before the deletion, the afterFind and afterDelete callbacks after it.
+// If the model has a soft delete field, the document is soft deleted instead unless HardDelete is called.
+func (f *Finder[T]) FindOneAndDelete(ctx context.Context, opts ...options.Lister[options.FindOneAndDeleteOptions]) (*T, error) {
+	currentTime := time.Now()
+	filter := f.scopedFilter()
+	t := new(T)
+	if f.sort != nil {
+		opts = append(opts, options.FindOneAndDelete().SetSort(f.sort))
+	}
+	if f.projection != nil {
+		opts = append(opts, options.FindOneAndDelete().SetProjection(f.projection))
+	}
+
+	globalOpContext := operation.NewOpContext(f.Collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithFilter(filter), operation.WithMongoOptions(opts), operation.WithModelHook(f.modelHook), operation.WithStartTime(currentTime), operation.WithFields(f.fields))
+	opContext := NewOpContext(f.Collection, filter, WithMongoOptions[T](opts), WithModelHook[T](f.modelHook), WithStartTime[T](currentTime), WithFields[T](f.fields))
+
+	err := f.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeFind, operation.OpTypeBeforeDelete)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *mongo.SingleResult
+	if fd := f.softDeleteField(); fd != nil {
+		updateOpts, err := softDeleteOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		result = f.Collection.FindOneAndUpdate(ctx, filter, softdelete.Updates(fd, currentTime), updateOpts)
+	} else {
+		result = f.Collection.FindOneAndDelete(ctx, filter, opts...)
+	}
+	err = result.Decode(t)
+	if err != nil {
+		return nil, err
+	}
+
+	globalOpContext.Result = result
+	globalOpContext.Doc = t
+	opContext.Result = result
+	opContext.Doc = t
+	err = f.PostActionHandler(ctx, globalOpContext, opContext, operation.OpTypeAfterFind, operation.OpTypeAfterDelete)
+	if err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// softDeleteOptions converts the options of FindOneAndDelete into the ones of FindOneAndUpdate, which soft deletes the document
+func softDeleteOptions(opts []options.Lister[options.FindOneAndDeleteOptions]) (*options.FindOneAndUpdateOptionsBuilder, error) {
+	args, err := utils.MergeOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	updateOpts := options.FindOneAndUpdate()
+	if args.Collation != nil {
+		updateOpts.SetCollation(args.Collation)
+	}
+	if args.Comment != nil {
+		updateOpts.SetComment(args.Comment)
+	}
+	if args.Projection != nil {
+		updateOpts.SetProjection(args.Projection)
+	}
+	if args.Sort != nil {
+		updateOpts.SetSort(args.Sort)
+	}
+	if args.Hint != nil {
+		updateOpts.SetHint(args.Hint)
+	}
+	if args.Let != nil {
+		updateOpts.SetLet(args.Let)
+	}
+	return updateOpts, nil
+}
+
+// replaceUpdateOptions converts the options of FindOneAndReplace into the ones of FindOneAndUpdate
+func replaceUpdateOptions(opts []options.Lister[options.FindOneAndReplaceOptions]) (*options.FindOneAndUpdateOptionsBuilder, error) {
+	args, err := utils.MergeOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	updateOpts := options.FindOneAndUpdate()
+	if args.BypassDocumentValidation != nil {
+		updateOpts.SetBypassDocumentValidation(*args.BypassDocumentValidation)
+	}
+	if args.Collation != nil {
+		updateOpts.SetCollation(args.Collation)
+	}
+	if args.Comment != nil {
+		updateOpts.SetComment(args.Comment)
+	}
+	if args.Projection != nil {
+		updateOpts.SetProjection(args.Projection)
+	}
+	if args.ReturnDocument != nil {
+		updateOpts.SetReturnDocument(*args.ReturnDocument)
+	}
+	if args.Sort != nil {
+		updateOpts.SetSort(args.Sort)
+	}
+	if args.Upsert != nil {
+		updateOpts.SetUpsert(*args.Upsert)
+	}
+	if args.Hint != nil {
+		updateOpts.SetHint(args.Hint)
+	}
+	if args.Let != nil {
+		updateOpts.SetLet(args.Let)
+	}
+	return updateOpts, nil
+}
+
+// FindOneAndReplace replaces the first document matching the filter in the order of the sort with the replacement and returns
+// the original document unless options.After is set.
+// The beforeFind and beforeUpdate callbacks are executed with the replacement as the updates before the replacement,
+// the afterFind and afterUpdate callbacks after it. With a version field, only the document of the version of the replacement is replaced,
+// ErrVersionConflict is returned if no document matches.
+// Like Updater.ReplaceOne, the zero _id and create time fields of the replacement keep the values of the replaced document.
+func (f *Finder[T]) FindOneAndReplace(ctx context.Context, opts ...options.Lister[options.FindOneAndReplaceOptions]) (*T, error) {
+	currentTime := time.Now()
+	filter, conflict := f.versionedFilter(f.scopedFilter())
+	t := new(T)
+	if f.sort != nil {
+		opts = append(opts, options.FindOneAndReplace().SetSort(f.sort))
+	}
+	if f.projection != nil {
+		opts = append(opts, options.FindOneAndReplace().SetProjection(f.projection))
+	}
+
+	replacement := f.replacement
+	// the callbacks modify a copy of a *T, which is copied back to the caller once it is written,
+	// so that a failed write doesn't leave the caller with an incremented version
+	var original *T
+	switch doc := replacement.(type) {
+	case T:
+		replacement = &doc
+	case *T:
+		if doc != nil {
+			original = doc
+			cp := *doc
+			replacement = &cp
+		}
+	}
+	if fd := version.Field(f.fields); fd != nil && conflict == nil {
+		if current, ok := version.Current(f.fields, replacement); ok {
+			filter, conflict = version.Scope(filter, fd, current), &VersionConflictError{Expected: current}
+		}
+	}
+	kept := replace.Kept(f.fields, replacement)
+
+	globalOpContext := operation.NewOpContext(f.Collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithFilter(filter), operation.WithUpdates(replacement), operation.WithMongoOptions(opts), operation.WithModelHook(f.modelHook), operation.WithStartTime(currentTime), operation.WithFields(f.fields))
+	opContext := NewOpContext(f.Collection, filter, WithUpdates[T](replacement), WithMongoOptions[T](opts), WithModelHook[T](f.modelHook), WithStartTime[T](currentTime), WithFields[T](f.fields))
 
 	err := f.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeFind, operation.OpTypeBeforeUpdate)
 	if err != nil {
 		return nil, err
 	}
 
-	result := f.Collection.FindOneAndUpdate(ctx, f.FilterObj, f.updates, opts...)
+	var result *mongo.SingleResult
+	if len(kept) > 0 {
+		// the document is replaced by an update pipeline which keeps the stored values of the kept fields
+		pipeline, err := replace.Pipeline(f.fields, replacement, kept)
+		if err != nil {
+			return nil, err
+		}
+		updateOpts, err := replaceUpdateOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		result = f.Collection.FindOneAndUpdate(ctx, filter, pipeline, updateOpts)
+	} else {
+		result = f.Collection.FindOneAndReplace(ctx, filter, replacement, opts...)
+	}
 	err = result.Decode(t)
 	if err != nil {
+		if conflict != nil && errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, conflict
+		}
 		return nil, err
 	}
+	if original != nil {
+		*original = *replacement.(*T)
+	}
 
 	globalOpContext.Result = result
 	globalOpContext.Doc = t
@@ -25,6 +25,7 @@ import (
 
 	"github.com/chenmingyong0423/go-mongox/v2/field"
 
+	"github.com/chenmingyong0423/go-mongox/v2/builder/aggregation"
 	"github.com/chenmingyong0423/go-mongox/v2/builder/update"
 
 	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
@@ -971,6 +972,33 @@ func TestFinder_e2e_Count(t *testing.T) {
 	}
 }
 
+func TestFinder_e2e_EstimatedCount(t *testing.T) {
+	collection := getCollection(t)
+	callbacks := callback.InitializeCallbacks()
+	var counted int64
+	callbacks.Register(operation.OpTypeAfterCount, "test", func(_ context.Context, opCtx *operation.OpContext, _ ...any) error {
+		counted = opCtx.Result.(int64)
+		return nil
+	})
+	finder := xfinder.NewFinder[TestUser](collection, callbacks, field.ParseFields(&TestUser{}))
+
+	ctx := context.Background()
+	insertManyResult, err := collection.InsertMany(ctx, []*TestUser{
+		{Name: "Mingyong Chen", Age: 24},
+		{Name: "burt", Age: 25},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.In("_id", insertManyResult.InsertedIDs...))
+		require.NoError(t, err)
+	}()
+
+	count, err := finder.EstimatedCount(ctx, options.EstimatedDocumentCount().SetComment("test"))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+	require.Equal(t, count, counted)
+}
+
 func TestFinder_e2e_Distinct(t *testing.T) {
 	collection := getCollection(t)
 	finder := xfinder.NewFinder[TestUser](collection, callback.InitializeCallbacks(), field.ParseFields(&TestUser{}))
@@ -1502,3 +1530,385 @@ func TestFinder_e2e_FindOneAndUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestFinder_e2e_Iter(t *testing.T) {
+	collection := getCollection(t)
+	ctx := context.Background()
+	_, err := collection.InsertMany(ctx, []any{
+		&TestUser{Name: "Mingyong Chen", Age: 18},
+		&TestUser{Name: "burt", Age: 19},
+		&TestUser{Name: "chenmingyong", Age: 20},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Build())
+		require.NoError(t, err)
+	}()
+
+	var afterFind int
+	finder := xfinder.NewFinder[TestUser](collection, callback.InitializeCallbacks(), nil)
+	finder.DBCallbacks.Register(operation.OpTypeAfterFind, "count", func(_ context.Context, opCtx *operation.OpContext, _ ...any) error {
+		afterFind++
+		return nil
+	})
+
+	it, err := finder.Filter(query.Gte("age", 19)).Sort(bson.D{{Key: "age", Value: 1}}).Iter(ctx)
+	require.NoError(t, err)
+	var names []string
+	for it.Next(ctx) {
+		user, err := it.Decode(ctx)
+		require.NoError(t, err)
+		names = append(names, user.Name)
+	}
+	require.NoError(t, it.Err())
+	require.NoError(t, it.Close(ctx))
+	require.Equal(t, []string{"burt", "chenmingyong"}, names)
+	require.Equal(t, 2, afterFind)
+}
+
+func TestFinder_e2e_FindInBatches(t *testing.T) {
+	collection := getCollection(t)
+	ctx := context.Background()
+	_, err := collection.InsertMany(ctx, []any{
+		&TestUser{Name: "Mingyong Chen", Age: 18},
+		&TestUser{Name: "burt", Age: 19},
+		&TestUser{Name: "chenmingyong", Age: 20},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Build())
+		require.NoError(t, err)
+	}()
+
+	var afterFind int
+	finder := xfinder.NewFinder[TestUser](collection, callback.InitializeCallbacks(), nil)
+	finder.DBCallbacks.Register(operation.OpTypeAfterFind, "count", func(_ context.Context, opCtx *operation.OpContext, _ ...any) error {
+		afterFind++
+		return nil
+	})
+
+	var batches [][]string
+	err = finder.Sort(bson.D{{Key: "age", Value: 1}}).FindInBatches(ctx, 2, func(users []*TestUser) error {
+		names := make([]string, 0, len(users))
+		for _, user := range users {
+			names = append(names, user.Name)
+		}
+		batches = append(batches, names)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"Mingyong Chen", "burt"}, {"chenmingyong"}}, batches)
+	require.Equal(t, 2, afterFind)
+
+	err = finder.FindInBatches(ctx, 2, func(users []*TestUser) error {
+		return errors.New("stop")
+	})
+	require.Equal(t, errors.New("stop"), err)
+}
+
+func TestFinder_e2e_Paginate(t *testing.T) {
+	collection := getCollection(t)
+	ctx := context.Background()
+	users := make([]any, 0, 5)
+	for i := 0; i < 5; i++ {
+		users = append(users, &TestUser{Name: fmt.Sprintf("user%d", i), Age: int64(18 + i)})
+	}
+	_, err := collection.InsertMany(ctx, users)
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Build())
+		require.NoError(t, err)
+	}()
+
+	for _, facet := range []bool{false, true} {
+		t.Run(fmt.Sprintf("facet %v", facet), func(t *testing.T) {
+			finder := xfinder.NewFinder[TestUser](collection, callback.InitializeCallbacks(), nil)
+			if facet {
+				finder.FacetPagination()
+			}
+			page, err := finder.Filter(query.Gte("age", 19)).Sort(bson.D{{Key: "age", Value: -1}}).Paginate(ctx, 2, 3)
+			require.NoError(t, err)
+			require.Len(t, page.Items, 1)
+			require.Equal(t, "user1", page.Items[0].Name)
+			require.Equal(t, int64(4), page.Total)
+			require.Equal(t, int64(2), page.TotalPages)
+			require.False(t, page.HasNext)
+
+			page, err = finder.Paginate(ctx, 1, 3)
+			require.NoError(t, err)
+			require.Len(t, page.Items, 3)
+			require.Equal(t, "user4", page.Items[0].Name)
+			require.True(t, page.HasNext)
+		})
+	}
+}
+
+func TestFinder_e2e_PageByCursor(t *testing.T) {
+	collection := getCollection(t)
+	ctx := context.Background()
+	users := make([]any, 0, 6)
+	for i := 0; i < 5; i++ {
+		users = append(users, &TestUser{Name: fmt.Sprintf("user%d", i), Age: int64(18 + i/2)})
+	}
+	// a document without the sort key, which the server sorts as null
+	users = append(users, bson.M{"name": "user5"})
+	_, err := collection.InsertMany(ctx, users)
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Build())
+		require.NoError(t, err)
+	}()
+
+	pageAll := func(sort string, size int64, projection any) []string {
+		var (
+			names []string
+			token string
+		)
+		for {
+			finder := xfinder.NewFinder[TestUser](collection, callback.InitializeCallbacks(), nil).CursorSecret([]byte("secret")).Sort(sort).Projection(projection)
+			if token != "" {
+				finder.After(token)
+			}
+			page, err := finder.PageByCursor(ctx, size)
+			require.NoError(t, err)
+			for _, item := range page.Items {
+				names = append(names, item.Name)
+			}
+			require.Equal(t, page.HasNext, page.NextToken != "")
+			if !page.HasNext {
+				return names
+			}
+			token = page.NextToken
+		}
+	}
+	require.Equal(t, []string{"user4", "user2", "user3", "user0", "user1", "user5"}, pageAll("-age", 2, nil))
+	require.Equal(t, []string{"user5", "user0", "user1", "user2", "user3", "user4"}, pageAll("age", 1, nil))
+	// the sort key left out of the projection is kept as the position of the next page
+	require.Equal(t, []string{"user4", "user2", "user3", "user0", "user1", "user5"}, pageAll("-age", 2, bson.D{{Key: "name", Value: 1}}))
+	require.Equal(t, []string{"user5", "user0", "user1", "user2", "user3", "user4"}, pageAll("age", 1, bson.M{"age": 0}))
+
+	_, err = xfinder.NewFinder[TestUser](collection, callback.InitializeCallbacks(), nil).CursorSecret([]byte("secret")).Sort("age").After("token").PageByCursor(ctx, 2)
+	require.Equal(t, xfinder.ErrInvalidCursor, err)
+	_, err = xfinder.NewFinder[TestUser](collection, callback.InitializeCallbacks(), nil).PageByCursor(ctx, 2)
+	require.Equal(t, xfinder.ErrNoCursorSecret, err)
+}
+
+type versionedUser struct {
+	ID      bson.ObjectID `bson:"_id,omitempty"`
+	Name    string        `bson:"name"`
+	Version int64         `bson:"version" mongox:"version"`
+}
+
+func TestFinder_e2e_FindOneAndUpdate_Version(t *testing.T) {
+	collection := getCollection(t)
+	ctx := context.Background()
+	id := bson.NewObjectID()
+	_, err := collection.InsertOne(ctx, &versionedUser{ID: id, Name: "cmy"})
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Build())
+		require.NoError(t, err)
+	}()
+
+	newFinder := func() *xfinder.Finder[versionedUser] {
+		return xfinder.NewFinder[versionedUser](collection, callback.InitializeCallbacks(), field.ParseFields(versionedUser{}))
+	}
+
+	user, err := newFinder().Filter(query.Id(id)).Version(int64(0)).Updates(update.Set("name", "chenmingyong")).
+		FindOneAndUpdate(ctx, options.FindOneAndUpdate().SetReturnDocument(options.After))
+	require.NoError(t, err)
+	require.Equal(t, &versionedUser{ID: id, Name: "chenmingyong", Version: 1}, user)
+
+	_, err = newFinder().Filter(query.Id(id)).Version(int64(0)).Updates(update.Set("name", "Mingyong Chen")).FindOneAndUpdate(ctx)
+	require.ErrorIs(t, err, xfinder.ErrVersionConflict)
+	var conflict *xfinder.VersionConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.Equal(t, int64(0), conflict.Expected)
+
+	// the stale replacement isn't written, so its version isn't incremented
+	replacement := &versionedUser{ID: id, Name: "cmy", Version: 0}
+	_, err = newFinder().Filter(query.Id(id)).Replacement(replacement).FindOneAndReplace(ctx)
+	require.ErrorIs(t, err, xfinder.ErrVersionConflict)
+	require.Equal(t, int64(0), replacement.Version)
+
+	replacement.Version = 1
+	_, err = newFinder().Filter(query.Id(id)).Replacement(replacement).FindOneAndReplace(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), replacement.Version)
+}
+
+func TestFinder_e2e_FindOneAndUpdate_Pipeline(t *testing.T) {
+	collection := getCollection(t)
+	ctx := context.Background()
+	id := bson.NewObjectID()
+	_, err := collection.InsertOne(ctx, &versionedUser{ID: id, Name: "cmy"})
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Build())
+		require.NoError(t, err)
+	}()
+
+	finder := xfinder.NewFinder[versionedUser](collection, callback.InitializeCallbacks(), field.ParseFields(versionedUser{}))
+	pipeline := aggregation.NewStageBuilder().Set(bson.D{{Key: "name", Value: bson.D{{Key: "$concat", Value: bson.A{"$name", "!"}}}}}).Build()
+	user, err := finder.Filter(query.Id(id)).Updates(pipeline).FindOneAndUpdate(ctx, options.FindOneAndUpdate().SetReturnDocument(options.After))
+	require.NoError(t, err)
+	// the version is incremented by the stage appended to the pipeline
+	require.Equal(t, &versionedUser{ID: id, Name: "cmy!", Version: 1}, user)
+}
+
+func TestFinder_e2e_FindOneAndDelete(t *testing.T) {
+	collection := getCollection(t)
+	ctx := context.Background()
+	_, err := collection.InsertMany(ctx, []any{
+		&TestUser{Name: "cmy", Age: 18},
+		&TestUser{Name: "chenmingyong", Age: 24},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Build())
+		require.NoError(t, err)
+	}()
+
+	var opTypes []operation.OpType
+	callbacks := callback.InitializeCallbacks()
+	for _, opType := range []operation.OpType{operation.OpTypeBeforeFind, operation.OpTypeBeforeDelete, operation.OpTypeAfterFind, operation.OpTypeAfterDelete} {
+		opType := opType
+		callbacks.Register(opType, "record", func(_ context.Context, _ *operation.OpContext, _ ...any) error {
+			opTypes = append(opTypes, opType)
+			return nil
+		})
+	}
+
+	user, err := xfinder.NewFinder[TestUser](collection, callbacks, nil).Filter(query.NewBuilder().Build()).
+		Sort(bson.D{{Key: "age", Value: -1}}).Projection(bson.D{{Key: "name", Value: 1}}).FindOneAndDelete(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "chenmingyong", user.Name)
+	require.Zero(t, user.Age)
+	require.Equal(t, []operation.OpType{operation.OpTypeBeforeFind, operation.OpTypeBeforeDelete, operation.OpTypeAfterFind, operation.OpTypeAfterDelete}, opTypes)
+
+	count, err := collection.CountDocuments(ctx, query.NewBuilder().Build())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	_, err = xfinder.NewFinder[TestUser](collection, callback.InitializeCallbacks(), nil).Filter(query.Eq("name", "chenmingyong")).FindOneAndDelete(ctx)
+	require.ErrorIs(t, err, mongo.ErrNoDocuments)
+}
+
+type softDeleteUser struct {
+	ID        bson.ObjectID `bson:"_id,omitempty"`
+	Name      string        `bson:"name"`
+	DeletedAt time.Time     `bson:"deleted_at,omitempty"`
+}
+
+func TestFinder_e2e_FindOneAndDelete_SoftDelete(t *testing.T) {
+	collection := getCollection(t)
+	ctx := context.Background()
+	_, err := collection.InsertMany(ctx, []any{
+		&softDeleteUser{Name: "cmy"},
+		&softDeleteUser{Name: "chenmingyong"},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Build())
+		require.NoError(t, err)
+	}()
+	fields := field.ParseFields(softDeleteUser{})
+
+	// the document is soft deleted by default
+	user, err := xfinder.NewFinder[softDeleteUser](collection, callback.InitializeCallbacks(), fields).Filter(query.Eq("name", "cmy")).FindOneAndDelete(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "cmy", user.Name)
+	count, err := collection.CountDocuments(ctx, query.Eq("name", "cmy"))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+	_, err = xfinder.NewFinder[softDeleteUser](collection, callback.InitializeCallbacks(), fields).Filter(query.Eq("name", "cmy")).FindOneAndDelete(ctx)
+	require.ErrorIs(t, err, mongo.ErrNoDocuments)
+
+	// the document is removed physically with HardDelete, such as claiming and removing a job from a queue
+	user, err = xfinder.NewFinder[softDeleteUser](collection, callback.InitializeCallbacks(), fields).Filter(query.Eq("name", "chenmingyong")).HardDelete().FindOneAndDelete(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "chenmingyong", user.Name)
+	count, err = collection.CountDocuments(ctx, query.Eq("name", "chenmingyong"))
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
+func TestFinder_e2e_FindOneAndReplace(t *testing.T) {
+	collection := getCollection(t)
+	ctx := context.Background()
+	createdAt := time.Now().Add(-time.Hour).Truncate(time.Millisecond).UTC()
+	_, err := collection.InsertMany(ctx, []any{
+		&TestUser{Name: "cmy", Age: 18, CreatedAt: createdAt},
+		&TestUser{Name: "chenmingyong", Age: 24, CreatedAt: createdAt},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Build())
+		require.NoError(t, err)
+	}()
+
+	var opTypes []operation.OpType
+	callbacks := callback.InitializeCallbacks()
+	for _, opType := range []operation.OpType{operation.OpTypeBeforeFind, operation.OpTypeBeforeUpdate, operation.OpTypeAfterFind, operation.OpTypeAfterUpdate} {
+		opType := opType
+		callbacks.Register(opType, "record", func(_ context.Context, _ *operation.OpContext, _ ...any) error {
+			opTypes = append(opTypes, opType)
+			return nil
+		})
+	}
+
+	user, err := xfinder.NewFinder[TestUser](collection, callbacks, field.ParseFields(TestUser{})).Filter(query.NewBuilder().Build()).
+		Sort(bson.D{{Key: "age", Value: 1}}).Replacement(&TestUser{Name: "Mingyong Chen", Age: 30}).FindOneAndReplace(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "cmy", user.Name)
+	require.Equal(t, []operation.OpType{operation.OpTypeBeforeFind, operation.OpTypeBeforeUpdate, operation.OpTypeAfterFind, operation.OpTypeAfterUpdate}, opTypes)
+
+	replaced := new(TestUser)
+	require.NoError(t, collection.FindOne(ctx, query.Id(user.ID)).Decode(replaced))
+	require.Equal(t, "Mingyong Chen", replaced.Name)
+	require.Equal(t, int64(30), replaced.Age)
+	require.False(t, replaced.UpdatedAt.IsZero())
+	// the zero _id and create time of the replacement keep the stored values
+	require.Equal(t, createdAt, replaced.CreatedAt.UTC())
+}
+
+func TestFinder_e2e_FindAs(t *testing.T) {
+	collection := getCollection(t)
+	finder := xfinder.NewFinder[TestUser](collection, callback.InitializeCallbacks(), field.ParseFields(&TestUser{}))
+
+	type userView struct {
+		Name string `bson:"name"`
+		Age  int64
+	}
+	type nameView struct {
+		ID   bson.ObjectID `bson:"_id"`
+		Name string        `bson:"name"`
+	}
+
+	ctx := context.Background()
+	insertManyResult, err := collection.InsertMany(ctx, []*TestUser{
+		{Name: "Mingyong Chen", Age: 24},
+		{Name: "burt", Age: 25},
+		{Name: "chenmingyong", Age: 26},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.In("_id", insertManyResult.InsertedIDs...))
+		require.NoError(t, err)
+	}()
+
+	t.Run("find as", func(t *testing.T) {
+		users, err := xfinder.FindAs[TestUser, userView](ctx, finder.Filter(query.Gt("age", 24)).Sort(bson.D{{Key: "age", Value: -1}}).Limit(1))
+		require.NoError(t, err)
+		require.Equal(t, []*userView{{Name: "chenmingyong", Age: 26}}, users)
+	})
+	t.Run("find one as", func(t *testing.T) {
+		user, err := xfinder.FindOneAs[TestUser, nameView](ctx, finder.Filter(query.Eq("name", "burt")))
+		require.NoError(t, err)
+		require.Equal(t, &nameView{ID: insertManyResult.InsertedIDs[1].(bson.ObjectID), Name: "burt"}, user)
+	})
+	t.Run("no document", func(t *testing.T) {
+		_, err := xfinder.FindOneAs[TestUser, nameView](ctx, finder.Filter(query.Eq("name", "unknown")))
+		require.ErrorIs(t, err, mongo.ErrNoDocuments)
+	})
+}
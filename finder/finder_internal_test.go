@@ -0,0 +1,296 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/callback"
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+func Test_softDeleteOptions(t *testing.T) {
+	updateOpts, err := softDeleteOptions([]options.Lister[options.FindOneAndDeleteOptions]{
+		options.FindOneAndDelete().SetSort(bson.D{{Key: "age", Value: 1}}).SetProjection(bson.D{{Key: "name", Value: 1}}),
+		nil,
+		options.FindOneAndDelete().SetComment("claim").SetHint("age_1").SetLet(bson.M{"x": 1}).SetCollation(&options.Collation{Locale: "en"}),
+	})
+	require.NoError(t, err)
+
+	args := new(options.FindOneAndUpdateOptions)
+	for _, setter := range updateOpts.List() {
+		require.NoError(t, setter(args))
+	}
+	assert.Equal(t, bson.D{{Key: "age", Value: 1}}, args.Sort)
+	assert.Equal(t, bson.D{{Key: "name", Value: 1}}, args.Projection)
+	assert.Equal(t, "claim", args.Comment)
+	assert.Equal(t, "age_1", args.Hint)
+	assert.Equal(t, bson.M{"x": 1}, args.Let)
+	assert.Equal(t, &options.Collation{Locale: "en"}, args.Collation)
+	assert.Nil(t, args.ReturnDocument)
+}
+
+func Test_replaceUpdateOptions(t *testing.T) {
+	updateOpts, err := replaceUpdateOptions([]options.Lister[options.FindOneAndReplaceOptions]{
+		options.FindOneAndReplace().SetSort(bson.D{{Key: "age", Value: 1}}).SetProjection(bson.D{{Key: "name", Value: 1}}).SetReturnDocument(options.After),
+		nil,
+		options.FindOneAndReplace().SetUpsert(true).SetBypassDocumentValidation(true).SetComment("replace").SetHint("age_1").SetLet(bson.M{"x": 1}).SetCollation(&options.Collation{Locale: "en"}),
+	})
+	require.NoError(t, err)
+
+	args := new(options.FindOneAndUpdateOptions)
+	for _, setter := range updateOpts.List() {
+		require.NoError(t, setter(args))
+	}
+	after, upsert, bypass := options.After, true, true
+	assert.Equal(t, &options.FindOneAndUpdateOptions{
+		BypassDocumentValidation: &bypass,
+		Collation:                &options.Collation{Locale: "en"},
+		Comment:                  "replace",
+		Projection:               bson.D{{Key: "name", Value: 1}},
+		ReturnDocument:           &after,
+		Sort:                     bson.D{{Key: "age", Value: 1}},
+		Upsert:                   &upsert,
+		Hint:                     "age_1",
+		Let:                      bson.M{"x": 1},
+	}, args)
+}
+
+func TestFinder_CountAndDistinct_Callbacks(t *testing.T) {
+	client, err := mongo.Connect()
+	require.NoError(t, err)
+	collection := client.Database("db-test").Collection("test_user")
+	errCallback := errors.New("callback error")
+
+	testCases := []struct {
+		name   string
+		opType operation.OpType
+		call   func(f *Finder[cursorUser]) error
+	}{
+		{
+			name:   "count",
+			opType: operation.OpTypeBeforeCount,
+			call: func(f *Finder[cursorUser]) error {
+				_, err := f.Count(context.Background())
+				return err
+			},
+		},
+		{
+			name:   "estimated count",
+			opType: operation.OpTypeBeforeCount,
+			call: func(f *Finder[cursorUser]) error {
+				_, err := f.EstimatedCount(context.Background())
+				return err
+			},
+		},
+		{
+			name:   "distinct",
+			opType: operation.OpTypeBeforeDistinct,
+			call: func(f *Finder[cursorUser]) error {
+				return f.Distinct(context.Background(), "name").Err()
+			},
+		},
+		{
+			name:   "distinct with parse",
+			opType: operation.OpTypeBeforeDistinct,
+			call: func(f *Finder[cursorUser]) error {
+				var names []string
+				return f.DistinctWithParse(context.Background(), "name", &names)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			callbacks := callback.InitializeCallbacks()
+			var filter any
+			callbacks.Register(tc.opType, "test", func(_ context.Context, opCtx *operation.OpContext, _ ...any) error {
+				filter = opCtx.Filter
+				return errCallback
+			})
+			f := NewFinder[cursorUser](collection, callbacks, nil).Filter(bson.M{"name": "cmy"}).(*Finder[cursorUser])
+
+			if tc.name == "distinct" {
+				// the driver wraps the error reported by the result
+				assert.ErrorIs(t, tc.call(f), errCallback)
+			} else {
+				assert.Equal(t, errCallback, tc.call(f))
+			}
+			if tc.name == "estimated count" {
+				assert.Nil(t, filter)
+			} else {
+				assert.Equal(t, bson.M{"name": "cmy"}, filter)
+			}
+		})
+	}
+}
+
+type softDeleteUser struct {
+	ID        bson.ObjectID `bson:"_id,omitempty"`
+	Name      string        `bson:"name"`
+	DeletedAt time.Time     `bson:"deleted_at,omitempty"`
+}
+
+func TestFinder_SoftDeleteScope(t *testing.T) {
+	client, err := mongo.Connect()
+	require.NoError(t, err)
+	collection := client.Database("db-test").Collection("test_user")
+	errCallback := errors.New("callback error")
+	notDeleted := bson.E{Key: "deleted_at", Value: bson.D{{Key: "$in", Value: bson.A{nil, time.Time{}}}}}
+
+	testCases := []struct {
+		name     string
+		opType   operation.OpType
+		unscoped bool
+		call     func(f IFinder[softDeleteUser]) error
+		want     any
+	}{
+		{
+			name:   "find one",
+			opType: operation.OpTypeBeforeFind,
+			call: func(f IFinder[softDeleteUser]) error {
+				_, err := f.FindOne(context.Background())
+				return err
+			},
+			want: bson.D{{Key: "name", Value: "cmy"}, notDeleted},
+		},
+		{
+			name:   "find",
+			opType: operation.OpTypeBeforeFind,
+			call: func(f IFinder[softDeleteUser]) error {
+				_, err := f.Find(context.Background())
+				return err
+			},
+			want: bson.D{{Key: "name", Value: "cmy"}, notDeleted},
+		},
+		{
+			name:   "count",
+			opType: operation.OpTypeBeforeCount,
+			call: func(f IFinder[softDeleteUser]) error {
+				_, err := f.Count(context.Background())
+				return err
+			},
+			want: bson.D{{Key: "name", Value: "cmy"}, notDeleted},
+		},
+		{
+			name:     "unscoped find one",
+			opType:   operation.OpTypeBeforeFind,
+			unscoped: true,
+			call: func(f IFinder[softDeleteUser]) error {
+				_, err := f.FindOne(context.Background())
+				return err
+			},
+			want: bson.D{{Key: "name", Value: "cmy"}},
+		},
+		{
+			name:     "unscoped find",
+			opType:   operation.OpTypeBeforeFind,
+			unscoped: true,
+			call: func(f IFinder[softDeleteUser]) error {
+				_, err := f.Find(context.Background())
+				return err
+			},
+			want: bson.D{{Key: "name", Value: "cmy"}},
+		},
+		{
+			name:     "unscoped count",
+			opType:   operation.OpTypeBeforeCount,
+			unscoped: true,
+			call: func(f IFinder[softDeleteUser]) error {
+				_, err := f.Count(context.Background())
+				return err
+			},
+			want: bson.D{{Key: "name", Value: "cmy"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			callbacks := callback.InitializeCallbacks()
+			var filter any
+			callbacks.Register(tc.opType, "test", func(_ context.Context, opCtx *operation.OpContext, _ ...any) error {
+				filter = opCtx.Filter
+				return errCallback
+			})
+			f := NewFinder[softDeleteUser](collection, callbacks, field.ParseFields(softDeleteUser{})).Filter(bson.D{{Key: "name", Value: "cmy"}})
+			if tc.unscoped {
+				f = f.Unscoped()
+			}
+
+			assert.Equal(t, errCallback, tc.call(f))
+			assert.Equal(t, tc.want, filter)
+		})
+	}
+}
+
+func TestFinder_scopedFilter(t *testing.T) {
+	notDeleted := bson.E{Key: "deleted_at", Value: bson.D{{Key: "$in", Value: bson.A{nil, time.Time{}}}}}
+	fields := field.ParseFields(softDeleteUser{})
+
+	// the filter on the soft delete field is kept as it is
+	deleted := bson.D{{Key: "deleted_at", Value: bson.D{{Key: "$ne", Value: nil}}}}
+	assert.Equal(t, deleted, NewFinder[softDeleteUser](nil, nil, fields).Filter(deleted).(*Finder[softDeleteUser]).scopedFilter())
+
+	assert.Equal(t, bson.D{notDeleted}, NewFinder[softDeleteUser](nil, nil, fields).scopedFilter())
+	assert.Equal(t, bson.D{}, NewFinder[softDeleteUser](nil, nil, fields).Unscoped().(*Finder[softDeleteUser]).scopedFilter())
+	// the models without a soft delete field are not scoped
+	assert.Equal(t, bson.D{}, NewFinder[cursorUser](nil, nil, field.ParseFields(cursorUser{})).scopedFilter())
+}
+
+func TestFinder_softDeleteField(t *testing.T) {
+	fields := field.ParseFields(softDeleteUser{})
+	fd := NewFinder[softDeleteUser](nil, nil, fields).softDeleteField()
+	require.NotNil(t, fd)
+	assert.Equal(t, "deleted_at", fd.MongoField)
+
+	assert.Nil(t, NewFinder[softDeleteUser](nil, nil, fields).HardDelete().(*Finder[softDeleteUser]).softDeleteField())
+	assert.Nil(t, NewFinder[cursorUser](nil, nil, field.ParseFields(cursorUser{})).softDeleteField())
+}
+
+type versionedUser struct {
+	ID      bson.ObjectID `bson:"_id,omitempty"`
+	Version int64         `bson:"version" mongox:"version"`
+}
+
+func TestFinder_FindOneAndReplace_Version(t *testing.T) {
+	client, err := mongo.Connect()
+	require.NoError(t, err)
+	collection := client.Database("db-test").Collection("test_user")
+	errCallback := errors.New("callback error")
+
+	callbacks := callback.InitializeCallbacks()
+	var filter, updates any
+	callbacks.Register(operation.OpTypeBeforeUpdate, "test", func(_ context.Context, opCtx *operation.OpContext, _ ...any) error {
+		filter, updates = opCtx.Filter, opCtx.Updates
+		return errCallback
+	})
+	id := bson.NewObjectID()
+	replacement := &versionedUser{ID: id, Version: 2}
+	_, err = NewFinder[versionedUser](collection, callbacks, field.ParseFields(versionedUser{})).Filter(bson.D{{Key: "_id", Value: id}}).Replacement(replacement).FindOneAndReplace(context.Background())
+	assert.Equal(t, errCallback, err)
+
+	assert.Equal(t, bson.D{{Key: "_id", Value: id}, {Key: "version", Value: int64(2)}}, filter)
+	assert.Equal(t, &versionedUser{ID: id, Version: 3}, updates)
+	// the replacement of the caller is only modified once it is written
+	assert.Equal(t, &versionedUser{ID: id, Version: 2}, replacement)
+}
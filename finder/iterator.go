@@ -0,0 +1,148 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finder
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var ErrInvalidBatchSize = errors.New("mongox: the batch size must be greater than 0")
+
+// Iterator iterates over the documents found by the finder one at a time,
+// so that the documents are never loaded into memory all together
+type Iterator[T any] struct {
+	finder *Finder[T]
+	cursor *mongo.Cursor
+
+	globalOpContext *operation.OpContext
+	opContext       *OpContext[T]
+}
+
+// Iter executes the query and returns an iterator over the documents found.
+// The beforeFind callbacks are executed once, and the afterFind callbacks are executed for every document decoded.
+// The iterator must be closed after use.
+func (f *Finder[T]) Iter(ctx context.Context, opts ...options.Lister[options.FindOptions]) (*Iterator[T], error) {
+	currentTime := time.Now()
+	filter := f.scopedFilter()
+	opts = f.findOptions(opts)
+
+	globalOpContext := operation.NewOpContext(f.Collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithFilter(filter), operation.WithMongoOptions(opts), operation.WithModelHook(f.modelHook), operation.WithStartTime(currentTime), operation.WithFields(f.fields))
+	opContext := NewOpContext(f.Collection, filter, WithMongoOptions[T](opts), WithModelHook[T](f.modelHook), WithStartTime[T](currentTime), WithFields[T](f.fields))
+	err := f.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeFind)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := f.Collection.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Iterator[T]{finder: f, cursor: cursor, globalOpContext: globalOpContext, opContext: opContext}, nil
+}
+
+// Next moves to the next document, it returns false when there are no more documents or an error occurs
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	return it.cursor.Next(ctx)
+}
+
+// Decode decodes the current document and executes the afterFind callbacks and hooks for it
+func (it *Iterator[T]) Decode(ctx context.Context) (*T, error) {
+	t := new(T)
+	if err := it.cursor.Decode(t); err != nil {
+		return nil, err
+	}
+
+	globalOpContext, opContext := *it.globalOpContext, *it.opContext
+	globalOpContext.Result = it.cursor
+	globalOpContext.Doc = t
+	opContext.Result = it.cursor
+	opContext.Doc = t
+	if err := it.finder.PostActionHandler(ctx, &globalOpContext, &opContext, operation.OpTypeAfterFind); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Err returns the last error of the cursor
+func (it *Iterator[T]) Err() error {
+	return it.cursor.Err()
+}
+
+func (it *Iterator[T]) Close(ctx context.Context) error {
+	return it.cursor.Close(ctx)
+}
+
+// afterFindBatch executes the afterFind callbacks and hooks once for the batch
+func (it *Iterator[T]) afterFindBatch(ctx context.Context, batch []*T) error {
+	globalOpContext, opContext := *it.globalOpContext, *it.opContext
+	globalOpContext.Result = it.cursor
+	globalOpContext.Doc = batch
+	opContext.Result = it.cursor
+	opContext.Docs = batch
+	return it.finder.PostActionHandler(ctx, &globalOpContext, &opContext, operation.OpTypeAfterFind)
+}
+
+// FindInBatches executes the query and calls fn with the documents found, at most size documents at a time.
+// The afterFind callbacks and hooks are executed once for every batch before fn is called,
+// and the iteration stops at the first error returned by fn.
+func (f *Finder[T]) FindInBatches(ctx context.Context, size int, fn func(docs []*T) error, opts ...options.Lister[options.FindOptions]) error {
+	if size <= 0 {
+		return ErrInvalidBatchSize
+	}
+	opts = append([]options.Lister[options.FindOptions]{options.Find().SetBatchSize(int32(size))}, opts...)
+	it, err := f.Iter(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = it.Close(ctx)
+	}()
+
+	handle := func(batch []*T) error {
+		if err := it.afterFindBatch(ctx, batch); err != nil {
+			return err
+		}
+		return fn(batch)
+	}
+
+	batch := make([]*T, 0, size)
+	for it.Next(ctx) {
+		t := new(T)
+		if err = it.cursor.Decode(t); err != nil {
+			return err
+		}
+		batch = append(batch, t)
+		if len(batch) == size {
+			if err = handle(batch); err != nil {
+				return err
+			}
+			batch = make([]*T, 0, size)
+		}
+	}
+	if err = it.Err(); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return handle(batch)
+	}
+	return nil
+}
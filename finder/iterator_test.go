@@ -0,0 +1,126 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finder
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chenmingyong0423/go-mongox/v2/callback"
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type iterUser struct {
+	Name    string `bson:"name"`
+	visited bool
+}
+
+func (u *iterUser) AfterFind(_ context.Context) error {
+	u.visited = true
+	return nil
+}
+
+func newTestIterator(t *testing.T, f *Finder[iterUser], names ...string) *Iterator[iterUser] {
+	docs := make([]any, 0, len(names))
+	for _, name := range names {
+		docs = append(docs, bson.D{{Key: "name", Value: name}})
+	}
+	cursor, err := mongo.NewCursorFromDocuments(docs, nil, nil)
+	require.NoError(t, err)
+	return &Iterator[iterUser]{
+		finder:          f,
+		cursor:          cursor,
+		globalOpContext: operation.NewOpContext(f.Collection),
+		opContext:       NewOpContext[iterUser](f.Collection, nil),
+	}
+}
+
+func TestIterator_Decode(t *testing.T) {
+	var found []any
+	callbacks := callback.InitializeCallbacks()
+	callbacks.Register(operation.OpTypeAfterFind, "record", func(_ context.Context, opCtx *operation.OpContext, _ ...any) error {
+		found = append(found, opCtx.Doc)
+		return nil
+	})
+	f := NewFinder[iterUser](&mongo.Collection{}, callbacks, nil)
+	var hooked []string
+	f.RegisterAfterHooks(func(_ context.Context, opContext *OpContext[iterUser], _ ...any) error {
+		hooked = append(hooked, opContext.Doc.Name)
+		return nil
+	})
+
+	it := newTestIterator(t, f, "cmy", "chenmingyong")
+	var users []*iterUser
+	for it.Next(context.Background()) {
+		user, err := it.Decode(context.Background())
+		require.NoError(t, err)
+		users = append(users, user)
+	}
+	require.NoError(t, it.Err())
+	require.NoError(t, it.Close(context.Background()))
+
+	require.Len(t, users, 2)
+	assert.True(t, users[0].visited)
+	assert.True(t, users[1].visited)
+	assert.Equal(t, []any{users[0], users[1]}, found)
+	assert.Equal(t, []string{"cmy", "chenmingyong"}, hooked)
+}
+
+func TestIterator_Decode_HookError(t *testing.T) {
+	f := NewFinder[iterUser](&mongo.Collection{}, callback.InitializeCallbacks(), nil)
+	f.RegisterAfterHooks(func(_ context.Context, _ *OpContext[iterUser], _ ...any) error {
+		return errors.New("after hook error")
+	})
+
+	it := newTestIterator(t, f, "cmy")
+	require.True(t, it.Next(context.Background()))
+	user, err := it.Decode(context.Background())
+	assert.Equal(t, errors.New("after hook error"), err)
+	assert.Nil(t, user)
+}
+
+func TestIterator_afterFindBatch(t *testing.T) {
+	var found []any
+	callbacks := callback.InitializeCallbacks()
+	callbacks.Register(operation.OpTypeAfterFind, "record", func(_ context.Context, opCtx *operation.OpContext, _ ...any) error {
+		found = append(found, opCtx.Doc)
+		return nil
+	})
+	f := NewFinder[iterUser](&mongo.Collection{}, callbacks, nil)
+	var hooked [][]*iterUser
+	f.RegisterAfterHooks(func(_ context.Context, opContext *OpContext[iterUser], _ ...any) error {
+		hooked = append(hooked, opContext.Docs)
+		return nil
+	})
+
+	it := newTestIterator(t, f)
+	batch := []*iterUser{{Name: "cmy"}, {Name: "chenmingyong"}}
+	require.NoError(t, it.afterFindBatch(context.Background(), batch))
+	assert.True(t, batch[0].visited)
+	assert.True(t, batch[1].visited)
+	assert.Equal(t, []any{batch}, found)
+	assert.Equal(t, [][]*iterUser{batch}, hooked)
+}
+
+func TestFinder_FindInBatches_InvalidSize(t *testing.T) {
+	f := NewFinder[iterUser](&mongo.Collection{}, callback.InitializeCallbacks(), nil)
+	err := f.FindInBatches(context.Background(), 0, func([]*iterUser) error { return nil })
+	assert.Equal(t, ErrInvalidBatchSize, err)
+}
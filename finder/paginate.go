@@ -0,0 +1,176 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var ErrInvalidPage = errors.New("mongox: the page and the page size must be greater than 0")
+
+// Page is a page of the documents found by Paginate
+type Page[T any] struct {
+	Items []*T
+	// Total is the number of the documents matching the filter
+	Total      int64
+	Page       int64
+	PageSize   int64
+	TotalPages int64
+	HasNext    bool
+}
+
+func newPage[T any](items []*T, total, page, pageSize int64) *Page[T] {
+	totalPages := (total + pageSize - 1) / pageSize
+	return &Page[T]{
+		Items:      items,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+	}
+}
+
+// FacetPagination makes Paginate count and find the documents with a single $facet aggregation
+// instead of two concurrent queries
+func (f *Finder[T]) FacetPagination() IFinder[T] {
+	f.facet = true
+	return f
+}
+
+// Paginate finds the documents of the page, which starts from 1, along with the total number of the documents.
+// The filter and the sort of the finder are respected while the skip and the limit are replaced by the page.
+// The count and the find are executed concurrently unless ctx carries a session, which can't be used concurrently.
+func (f *Finder[T]) Paginate(ctx context.Context, page, pageSize int64) (*Page[T], error) {
+	if page < 1 || pageSize < 1 {
+		return nil, ErrInvalidPage
+	}
+	if f.facet {
+		return f.paginateByFacet(ctx, page, pageSize)
+	}
+
+	opts := make([]options.Lister[options.FindOptions], 0, 2)
+	if f.sort != nil {
+		opts = append(opts, options.Find().SetSort(f.sort))
+	}
+	opts = append(opts, options.Find().SetSkip((page-1)*pageSize).SetLimit(pageSize))
+
+	var (
+		items             []*T
+		total             int64
+		findErr, countErr error
+	)
+	if mongo.SessionFromContext(ctx) != nil {
+		items, findErr = f.find(ctx, f.scopedFilter(), opts)
+		if findErr == nil {
+			total, countErr = f.Count(ctx)
+		}
+	} else {
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			total, countErr = f.Count(ctx)
+		}()
+		items, findErr = f.find(ctx, f.scopedFilter(), opts)
+		wg.Wait()
+	}
+	if findErr != nil {
+		return nil, findErr
+	}
+	if countErr != nil {
+		return nil, countErr
+	}
+	return newPage(items, total, page, pageSize), nil
+}
+
+type facetResult[T any] struct {
+	Items []*T `bson:"items"`
+	Total []struct {
+		Count int64 `bson:"count"`
+	} `bson:"total"`
+}
+
+// facetPipeline returns the pipeline which finds the documents of the page and counts all the documents in one $facet stage
+func (f *Finder[T]) facetPipeline(filter any, page, pageSize int64) mongo.Pipeline {
+	if filter == nil {
+		filter = bson.D{}
+	}
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: filter}}}
+	if f.sort != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$sort", Value: f.sort}})
+	}
+	return append(pipeline, bson.D{{Key: "$facet", Value: bson.D{
+		{Key: "items", Value: bson.A{
+			bson.D{{Key: "$skip", Value: (page - 1) * pageSize}},
+			bson.D{{Key: "$limit", Value: pageSize}},
+		}},
+		{Key: "total", Value: bson.A{bson.D{{Key: "$count", Value: "count"}}}},
+	}}})
+}
+
+func (f *Finder[T]) paginateByFacet(ctx context.Context, page, pageSize int64) (*Page[T], error) {
+	currentTime := time.Now()
+	filter := f.scopedFilter()
+	pipeline := f.facetPipeline(filter, page, pageSize)
+
+	globalOpContext := operation.NewOpContext(f.Collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithFilter(filter), operation.WithPipeline(pipeline), operation.WithModelHook(f.modelHook), operation.WithStartTime(currentTime), operation.WithFields(f.fields))
+	opContext := NewOpContext(f.Collection, filter, WithModelHook[T](f.modelHook), WithStartTime[T](currentTime), WithFields[T](f.fields))
+	err := f.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeFind)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := f.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		_ = cursor.Close(ctx)
+	}(cursor, ctx)
+
+	results := make([]*facetResult[T], 0, 1)
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+	items, total := make([]*T, 0), int64(0)
+	if len(results) > 0 {
+		if results[0].Items != nil {
+			items = results[0].Items
+		}
+		if len(results[0].Total) > 0 {
+			total = results[0].Total[0].Count
+		}
+	}
+
+	globalOpContext.Result = cursor
+	globalOpContext.Doc = items
+	opContext.Result = cursor
+	opContext.Docs = items
+	err = f.PostActionHandler(ctx, globalOpContext, opContext, operation.OpTypeAfterFind)
+	if err != nil {
+		return nil, err
+	}
+	return newPage(items, total, page, pageSize), nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+func TestNewPage(t *testing.T) {
+	items := []*iterUser{{Name: "cmy"}}
+	testCases := []struct {
+		name                  string
+		total, page, pageSize int64
+		want                  *Page[iterUser]
+	}{
+		{
+			name:  "empty",
+			total: 0, page: 1, pageSize: 10,
+			want: &Page[iterUser]{Items: items, Total: 0, Page: 1, PageSize: 10, TotalPages: 0, HasNext: false},
+		},
+		{
+			name:  "first page of many",
+			total: 21, page: 1, pageSize: 10,
+			want: &Page[iterUser]{Items: items, Total: 21, Page: 1, PageSize: 10, TotalPages: 3, HasNext: true},
+		},
+		{
+			name:  "last page",
+			total: 20, page: 2, pageSize: 10,
+			want: &Page[iterUser]{Items: items, Total: 20, Page: 2, PageSize: 10, TotalPages: 2, HasNext: false},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, newPage(items, tc.total, tc.page, tc.pageSize))
+		})
+	}
+}
+
+func TestFinder_facetPipeline(t *testing.T) {
+	f := NewFinder[iterUser](&mongo.Collection{}, nil, nil)
+	facet := bson.D{{Key: "$facet", Value: bson.D{
+		{Key: "items", Value: bson.A{bson.D{{Key: "$skip", Value: int64(20)}}, bson.D{{Key: "$limit", Value: int64(10)}}}},
+		{Key: "total", Value: bson.A{bson.D{{Key: "$count", Value: "count"}}}},
+	}}}
+	assert.Equal(t, mongo.Pipeline{{{Key: "$match", Value: bson.D{}}}, facet}, f.facetPipeline(nil, 3, 10))
+
+	f.Sort(bson.D{{Key: "age", Value: -1}})
+	assert.Equal(t, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: "name", Value: "cmy"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "age", Value: -1}}}},
+		facet,
+	}, f.facetPipeline(bson.D{{Key: "name", Value: "cmy"}}, 3, 10))
+}
+
+func TestFinder_Paginate_InvalidPage(t *testing.T) {
+	f := NewFinder[iterUser](&mongo.Collection{}, nil, nil)
+	_, err := f.Paginate(context.Background(), 0, 10)
+	assert.Equal(t, ErrInvalidPage, err)
+	_, err = f.FacetPagination().Paginate(context.Background(), 1, 0)
+	assert.Equal(t, ErrInvalidPage, err)
+}
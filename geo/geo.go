@@ -0,0 +1,261 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package geo provides the GeoJSON objects stored in mongo and used by the geospatial query operators,
+// they are marshalled as {type: "Point", coordinates: [...]} and validated before being marshalled.
+// The zero values aren't validated and report IsZero, so that the unset fields tagged with omitempty are left out.
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	TypePoint        = "Point"
+	TypeLineString   = "LineString"
+	TypePolygon      = "Polygon"
+	TypeMultiPolygon = "MultiPolygon"
+)
+
+// ErrInvalidGeometry is returned when the coordinates of a GeoJSON object are invalid,
+// such as a latitude out of range or a polygon ring which isn't closed
+var ErrInvalidGeometry = errors.New("mongox: invalid geometry")
+
+// Geometry is a GeoJSON object
+type Geometry interface {
+	// Type returns the GeoJSON type, such as "Point"
+	Type() string
+	Validate() error
+}
+
+// Area is a GeoJSON object which has an area, so that it can be used by $geoWithin
+type Area interface {
+	Geometry
+	area()
+}
+
+// Position is a pair of longitude and latitude, in that order as GeoJSON requires
+type Position [2]float64
+
+func (p Position) Longitude() float64 {
+	return p[0]
+}
+
+func (p Position) Latitude() float64 {
+	return p[1]
+}
+
+// Validate reports whether the longitude is within [-180, 180] and the latitude within [-90, 90]
+func (p Position) Validate() error {
+	if math.IsNaN(p[0]) || p[0] < -180 || p[0] > 180 {
+		return fmt.Errorf("%w: longitude %v out of [-180, 180]", ErrInvalidGeometry, p[0])
+	}
+	if math.IsNaN(p[1]) || p[1] < -90 || p[1] > 90 {
+		return fmt.Errorf("%w: latitude %v out of [-90, 90]", ErrInvalidGeometry, p[1])
+	}
+	return nil
+}
+
+type Point struct {
+	Coordinates Position
+}
+
+func NewPoint(longitude, latitude float64) Point {
+	return Point{Coordinates: Position{longitude, latitude}}
+}
+
+func (p Point) Type() string {
+	return TypePoint
+}
+
+func (p Point) Validate() error {
+	return p.Coordinates.Validate()
+}
+
+// IsZero reports whether the point is at the zero position, note that omitempty leaves out the point (0, 0) as well
+func (p Point) IsZero() bool {
+	return p.Coordinates == Position{}
+}
+
+func (p Point) MarshalBSON() ([]byte, error) {
+	return marshal(p, p.Coordinates)
+}
+
+func (p *Point) UnmarshalBSON(data []byte) error {
+	return unmarshal(data, TypePoint, &p.Coordinates)
+}
+
+type LineString struct {
+	Coordinates []Position
+}
+
+func NewLineString(positions ...Position) LineString {
+	return LineString{Coordinates: positions}
+}
+
+func (l LineString) Type() string {
+	return TypeLineString
+}
+
+// Validate reports whether the line string has at least 2 valid positions
+func (l LineString) Validate() error {
+	if len(l.Coordinates) < 2 {
+		return fmt.Errorf("%w: a line string needs at least 2 positions, got %d", ErrInvalidGeometry, len(l.Coordinates))
+	}
+	return validatePositions(l.Coordinates)
+}
+
+func (l LineString) IsZero() bool {
+	return len(l.Coordinates) == 0
+}
+
+func (l LineString) MarshalBSON() ([]byte, error) {
+	return marshal(l, l.Coordinates)
+}
+
+func (l *LineString) UnmarshalBSON(data []byte) error {
+	return unmarshal(data, TypeLineString, &l.Coordinates)
+}
+
+// Polygon is made of linear rings, the first one is the exterior ring and the others are the holes within it
+type Polygon struct {
+	Coordinates [][]Position
+}
+
+func NewPolygon(rings ...[]Position) Polygon {
+	return Polygon{Coordinates: rings}
+}
+
+func (p Polygon) Type() string {
+	return TypePolygon
+}
+
+// Validate reports whether the polygon has at least one ring,
+// and every ring is closed with at least 4 valid positions
+func (p Polygon) Validate() error {
+	if len(p.Coordinates) == 0 {
+		return fmt.Errorf("%w: a polygon needs at least 1 ring", ErrInvalidGeometry)
+	}
+	for i, ring := range p.Coordinates {
+		if len(ring) < 4 {
+			return fmt.Errorf("%w: ring %d of the polygon needs at least 4 positions, got %d", ErrInvalidGeometry, i, len(ring))
+		}
+		if ring[0] != ring[len(ring)-1] {
+			return fmt.Errorf("%w: ring %d of the polygon isn't closed", ErrInvalidGeometry, i)
+		}
+		if err := validatePositions(ring); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p Polygon) IsZero() bool {
+	return len(p.Coordinates) == 0
+}
+
+func (p Polygon) MarshalBSON() ([]byte, error) {
+	return marshal(p, p.Coordinates)
+}
+
+func (p *Polygon) UnmarshalBSON(data []byte) error {
+	return unmarshal(data, TypePolygon, &p.Coordinates)
+}
+
+func (p Polygon) area() {}
+
+type MultiPolygon struct {
+	Coordinates [][][]Position
+}
+
+func NewMultiPolygon(polygons ...Polygon) MultiPolygon {
+	coordinates := make([][][]Position, 0, len(polygons))
+	for _, polygon := range polygons {
+		coordinates = append(coordinates, polygon.Coordinates)
+	}
+	return MultiPolygon{Coordinates: coordinates}
+}
+
+func (m MultiPolygon) Type() string {
+	return TypeMultiPolygon
+}
+
+// Validate reports whether the multi polygon has at least one polygon and all of them are valid
+func (m MultiPolygon) Validate() error {
+	if len(m.Coordinates) == 0 {
+		return fmt.Errorf("%w: a multi polygon needs at least 1 polygon", ErrInvalidGeometry)
+	}
+	for _, rings := range m.Coordinates {
+		if err := (Polygon{Coordinates: rings}).Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m MultiPolygon) IsZero() bool {
+	return len(m.Coordinates) == 0
+}
+
+func (m MultiPolygon) MarshalBSON() ([]byte, error) {
+	return marshal(m, m.Coordinates)
+}
+
+func (m *MultiPolygon) UnmarshalBSON(data []byte) error {
+	return unmarshal(data, TypeMultiPolygon, &m.Coordinates)
+}
+
+func (m MultiPolygon) area() {}
+
+func validatePositions(positions []Position) error {
+	for _, position := range positions {
+		if err := position.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type zeroer interface {
+	IsZero() bool
+}
+
+func marshal(geometry Geometry, coordinates any) ([]byte, error) {
+	if z, ok := geometry.(zeroer); !ok || !z.IsZero() {
+		if err := geometry.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return bson.Marshal(bson.D{{Key: "type", Value: geometry.Type()}, {Key: "coordinates", Value: coordinates}})
+}
+
+func unmarshal(data []byte, geometryType string, coordinates any) error {
+	raw := bson.Raw(data)
+	typeValue, err := raw.LookupErr("type")
+	if err != nil {
+		return fmt.Errorf("%w: missing type", ErrInvalidGeometry)
+	}
+	if t, ok := typeValue.StringValueOK(); !ok || t != geometryType {
+		return fmt.Errorf("%w: expects type %s, got %s", ErrInvalidGeometry, geometryType, typeValue)
+	}
+	coordinatesValue, err := raw.LookupErr("coordinates")
+	if err != nil {
+		return fmt.Errorf("%w: missing coordinates", ErrInvalidGeometry)
+	}
+	return coordinatesValue.Unmarshal(coordinates)
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var square = []Position{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}
+
+func TestGeometry_Validate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		geometry Geometry
+		wantErr  bool
+	}{
+		{name: "point", geometry: NewPoint(113.93, 22.53)},
+		{name: "longitude out of range", geometry: NewPoint(181, 0), wantErr: true},
+		{name: "latitude out of range", geometry: NewPoint(0, -90.5), wantErr: true},
+		{name: "nan", geometry: NewPoint(math.NaN(), 0), wantErr: true},
+		{name: "line string", geometry: NewLineString(Position{0, 0}, Position{1, 1})},
+		{name: "line string with one position", geometry: NewLineString(Position{0, 0}), wantErr: true},
+		{name: "polygon", geometry: NewPolygon(square)},
+		{name: "polygon without rings", geometry: NewPolygon(), wantErr: true},
+		{name: "polygon with a short ring", geometry: NewPolygon([]Position{{0, 0}, {1, 1}, {0, 0}}), wantErr: true},
+		{name: "polygon with an open ring", geometry: NewPolygon([]Position{{0, 0}, {1, 0}, {1, 1}, {0, 1}}), wantErr: true},
+		{name: "multi polygon", geometry: NewMultiPolygon(NewPolygon(square), NewPolygon(square))},
+		{name: "empty multi polygon", geometry: NewMultiPolygon(), wantErr: true},
+		{name: "multi polygon with an invalid polygon", geometry: NewMultiPolygon(NewPolygon(square), NewPolygon()), wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.geometry.Validate()
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidGeometry)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGeometry_MarshalBSON(t *testing.T) {
+	type store struct {
+		Location Point        `bson:"location"`
+		Route    LineString   `bson:"route"`
+		Area     Polygon      `bson:"area"`
+		Areas    MultiPolygon `bson:"areas"`
+	}
+	s := store{
+		Location: NewPoint(113.93, 22.53),
+		Route:    NewLineString(Position{0, 0}, Position{1, 1}),
+		Area:     NewPolygon(square),
+		Areas:    NewMultiPolygon(NewPolygon(square)),
+	}
+
+	data, err := bson.Marshal(s)
+	require.NoError(t, err)
+	raw := bson.Raw(data)
+	assert.Equal(t, `{"type": "Point","coordinates": [{"$numberDouble":"113.93"},{"$numberDouble":"22.53"}]}`, raw.Lookup("location").Document().String())
+	assert.Equal(t, "LineString", raw.Lookup("route", "type").StringValue())
+	assert.Equal(t, "Polygon", raw.Lookup("area", "type").StringValue())
+	assert.Equal(t, "MultiPolygon", raw.Lookup("areas", "type").StringValue())
+
+	var got store
+	require.NoError(t, bson.Unmarshal(data, &got))
+	assert.Equal(t, s, got)
+
+	_, err = bson.Marshal(store{Location: NewPoint(200, 0), Route: s.Route, Area: s.Area, Areas: s.Areas})
+	assert.ErrorIs(t, err, ErrInvalidGeometry)
+
+	data, err = bson.Marshal(store{})
+	require.NoError(t, err)
+	assert.Equal(t, "Polygon", bson.Raw(data).Lookup("area", "type").StringValue())
+
+	data, err = bson.Marshal(struct {
+		Location Point        `bson:"location,omitempty"`
+		Route    LineString   `bson:"route,omitempty"`
+		Area     Polygon      `bson:"area,omitempty"`
+		Areas    MultiPolygon `bson:"areas,omitempty"`
+	}{Area: NewPolygon(square)})
+	require.NoError(t, err)
+	assert.Equal(t, bson.Raw(mustMarshal(t, bson.D{{Key: "area", Value: NewPolygon(square)}})).String(), bson.Raw(data).String())
+
+	data, err = bson.Marshal(bson.M{"location": bson.M{"type": "Polygon", "coordinates": bson.A{}}})
+	require.NoError(t, err)
+	assert.ErrorIs(t, bson.Unmarshal(data, &got), ErrInvalidGeometry)
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	data, err := bson.Marshal(v)
+	require.NoError(t, err)
+	return data
+}
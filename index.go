@@ -0,0 +1,146 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongox
+
+import (
+	"context"
+
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/index"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// the index created by mongo for every collection, it is never stale
+const idIndexName = "_id_"
+
+// Indexer is implemented by the models which declare the indexes that can't be expressed by the mongox tags,
+// such as the compound indexes with descending keys and the partial indexes.
+type Indexer interface {
+	Indexes() []mongo.IndexModel
+}
+
+type syncIndexesOptions struct {
+	dropStale bool
+}
+
+type SyncIndexesOption func(*syncIndexesOptions)
+
+// WithDropStaleIndexes drops the stale indexes instead of only reporting them
+func WithDropStaleIndexes() SyncIndexesOption {
+	return func(o *syncIndexesOptions) {
+		o.dropStale = true
+	}
+}
+
+// SyncIndexesResult is the result of SyncIndexes
+type SyncIndexesResult struct {
+	// Created are the names of the indexes created
+	Created []string
+	// Stale are the names of the indexes in the collection which aren't declared by the model
+	// or differ from the declaration in the keys, uniqueness, TTL or partial filter
+	Stale []string
+	// Dropped are the names of the stale indexes dropped
+	Dropped []string
+}
+
+// Indexes returns the index models declared by the model,
+// which are the indexes of the mongox tags followed by the ones returned by Indexer.
+// An error is returned if the mongox tags declare an invalid index, such as a ttl on a compound index.
+func (c *Collection[T]) Indexes() ([]mongo.IndexModel, error) {
+	models, err := index.Models(c.fields)
+	if err != nil {
+		return nil, err
+	}
+	if indexer, ok := any(new(T)).(Indexer); ok {
+		models = append(models, indexer.Indexes()...)
+	}
+	return models, nil
+}
+
+// SyncIndexes makes the indexes of the collection match the ones declared by the model.
+// The missing indexes are created and the stale ones are reported, they are dropped with WithDropStaleIndexes.
+// An index that differs from its declaration is stale, so it's recreated only when the stale indexes are dropped.
+func (c *Collection[T]) SyncIndexes(ctx context.Context, opts ...SyncIndexesOption) (*SyncIndexesResult, error) {
+	o := &syncIndexesOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	models, err := c.Indexes()
+	if err != nil {
+		return nil, err
+	}
+	specs := make([]*index.Spec, len(models))
+	declared := make(map[string]*index.Spec, len(models))
+	for i, model := range models {
+		spec, err := index.NewSpec(model)
+		if err != nil {
+			return nil, err
+		}
+		specs[i] = spec
+		declared[spec.Name] = spec
+	}
+
+	indexView := c.collection.Indexes()
+	cursor, err := indexView.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var listed []bson.Raw
+	if err = cursor.All(ctx, &listed); err != nil {
+		return nil, err
+	}
+
+	result := &SyncIndexesResult{}
+	existing := make(map[string]bool, len(listed))
+	for _, raw := range listed {
+		spec, err := index.SpecOf(raw)
+		if err != nil {
+			return nil, err
+		}
+		want, ok := declared[spec.Name]
+		if spec.Name == idIndexName || (ok && want.Equal(spec)) {
+			existing[spec.Name] = true
+			continue
+		}
+		result.Stale = append(result.Stale, spec.Name)
+		if !o.dropStale {
+			// the stale index is kept, so the declared index with the same name or keys can't be created
+			existing[spec.Name] = true
+			for _, want := range specs {
+				if want.SameKeys(spec) {
+					existing[want.Name] = true
+				}
+			}
+			continue
+		}
+		if err = indexView.DropOne(ctx, spec.Name); err != nil {
+			return result, err
+		}
+		result.Dropped = append(result.Dropped, spec.Name)
+	}
+
+	missing := make([]mongo.IndexModel, 0, len(models))
+	for i, model := range models {
+		if !existing[specs[i].Name] {
+			missing = append(missing, model)
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+	result.Created, err = indexView.CreateMany(ctx, missing)
+	return result, err
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongox
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type indexerModel struct {
+	Name string `bson:"name" mongox:"index"`
+}
+
+func (*indexerModel) Indexes() []mongo.IndexModel {
+	return []mongo.IndexModel{{Keys: bson.D{{Key: "age", Value: -1}}}}
+}
+
+func TestCollection_Indexes(t *testing.T) {
+	db := NewClient(&mongo.Client{}, &Config{}).NewDatabase("db-test")
+
+	models, err := NewCollection[indexerModel](db, "collection-test").Indexes()
+	require.NoError(t, err)
+	assert.Len(t, models, 2)
+	assert.Equal(t, bson.D{{Key: "name", Value: 1}}, models[0].Keys)
+	assert.Equal(t, bson.D{{Key: "age", Value: -1}}, models[1].Keys)
+
+	models, err = NewCollection[any](db, "collection-test").Indexes()
+	require.NoError(t, err)
+	assert.Empty(t, models)
+
+	_, err = NewCollection[compoundTTLModel](db, "collection-test").Indexes()
+	assert.Error(t, err)
+}
+
+type compoundTTLModel struct {
+	TenantID string    `bson:"tenant_id" mongox:"index:idx_tenant_expire,compound"`
+	ExpireAt time.Time `bson:"expire_at" mongox:"index:idx_tenant_expire,compound,ttl:1h"`
+}
@@ -45,6 +45,11 @@ func Execute(ctx context.Context, opCtx *operation.OpContext, opType operation.O
 			return nil
 		}
 	case operation.OpTypeBeforeUpdate, operation.OpTypeBeforeUpsert:
+		// the stages can't be appended to the pipeline in place, so the updates are replaced
+		if updates, ok := pipelineUpdates(opCtx.Updates, opType, opCtx.StartTime, opCtx.Fields); ok {
+			opCtx.Updates = updates
+			return nil
+		}
 		return execute(ctx, opCtx.Updates, opType, opCtx.StartTime, opCtx.Fields, opts...)
 	}
 	return nil
@@ -0,0 +1,126 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package field
+
+import (
+	"sort"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/bsonx"
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// pipelineUpdates returns the pipeline-style updates with an extra $set stage of the update time fields and the version,
+// which also sets the id-less create time fields of the inserted document on upsert like $setOnInsert does.
+// The fields set by the $set and $addFields stages of the pipeline are kept as they are.
+// It returns false if the updates aren't a pipeline.
+func pipelineUpdates(updates any, opType operation.OpType, currentTime time.Time, fields []*field.Filed) (any, bool) {
+	stages, ok := pipelineStages(updates)
+	if !ok {
+		return nil, false
+	}
+	setFields := pipelineSetFields(stages)
+
+	stage := bson.D{}
+	appendField := func(key string, value any) {
+		if _, exist := setFields[key]; !exist {
+			stage = append(stage, bson.E{Key: key, Value: value})
+		}
+	}
+	updatedFields := findAdditionalFields(currentTime, fields, findUpdatedFields)
+	for _, key := range sortedKeys(updatedFields) {
+		appendField(key, updatedFields[key])
+	}
+	for _, key := range sortedKeys(findAdditionalFields(currentTime, fields, findVersionFields)) {
+		appendField(key, bson.D{{Key: "$add", Value: bson.A{bson.D{{Key: "$ifNull", Value: bson.A{"$" + key, 0}}}, 1}}})
+	}
+	if opType == operation.OpTypeBeforeUpsert {
+		createFields := findAdditionalFields(currentTime, fields, findUpsertFields)
+		for _, key := range sortedKeys(createFields) {
+			// the _id of the inserted document is generated by mongo
+			if key == "_id" {
+				continue
+			}
+			appendField(key, bson.D{{Key: "$ifNull", Value: bson.A{"$" + key, createFields[key]}}})
+		}
+	}
+	if len(stage) == 0 {
+		return updates, true
+	}
+	return appendStage(updates, bson.D{{Key: "$set", Value: stage}}), true
+}
+
+// pipelineStages returns the stages of the pipeline-style updates, such as mongo.Pipeline or bson.A of the stages
+func pipelineStages(updates any) ([]any, bool) {
+	var stages []any
+	switch u := updates.(type) {
+	case mongo.Pipeline:
+		for _, stage := range u {
+			stages = append(stages, stage)
+		}
+	case []bson.D:
+		for _, stage := range u {
+			stages = append(stages, stage)
+		}
+	case bson.A:
+		stages = u
+	case []any:
+		stages = u
+	default:
+		return nil, false
+	}
+	return stages, true
+}
+
+// pipelineSetFields returns the fields set by the $set and $addFields stages of the pipeline
+func pipelineSetFields(stages []any) map[string]struct{} {
+	setFields := make(map[string]struct{})
+	for _, stage := range stages {
+		m := bsonx.ToBsonM(stage)
+		for _, op := range []string{"$set", "$addFields"} {
+			for k := range bsonx.ToBsonM(m[op]) {
+				setFields[k] = struct{}{}
+			}
+		}
+	}
+	return setFields
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func appendStage(updates any, stage bson.D) any {
+	switch u := updates.(type) {
+	case mongo.Pipeline:
+		return append(u[:len(u):len(u)], stage)
+	case []bson.D:
+		return append(u[:len(u):len(u)], stage)
+	case bson.A:
+		return append(u[:len(u):len(u)], stage)
+	case []any:
+		return append(u[:len(u):len(u)], stage)
+	}
+	return updates
+}
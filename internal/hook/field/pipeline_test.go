@@ -0,0 +1,109 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package field
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type pipelineUser struct {
+	ID              bson.ObjectID `bson:"_id,omitempty" mongox:"autoID"`
+	Name            string        `bson:"name"`
+	CreatedAt       time.Time     `bson:"created_at"`
+	UpdatedAt       time.Time     `bson:"updated_at"`
+	UpdateMilliTime int64         `bson:"update_milli_time" mongox:"autoUpdateTime:milli"`
+	Version         int64         `bson:"version" mongox:"version"`
+}
+
+func TestExecute_pipeline(t *testing.T) {
+	now := time.Now()
+	fields := field.ParseFields(pipelineUser{})
+	setName := bson.D{{Key: "$set", Value: bson.D{{Key: "name", Value: "chenmingyong"}}}}
+	updateStage := bson.D{{Key: "$set", Value: bson.D{
+		{Key: "update_milli_time", Value: now.UnixMilli()},
+		{Key: "updated_at", Value: now},
+		{Key: "version", Value: bson.D{{Key: "$add", Value: bson.A{bson.D{{Key: "$ifNull", Value: bson.A{"$version", 0}}}, 1}}}},
+	}}}
+
+	testCases := []struct {
+		name    string
+		opType  operation.OpType
+		updates any
+		want    any
+	}{
+		{
+			name:    "update with mongo.Pipeline",
+			opType:  operation.OpTypeBeforeUpdate,
+			updates: mongo.Pipeline{setName},
+			want:    mongo.Pipeline{setName, updateStage},
+		},
+		{
+			name:    "update with bson.A",
+			opType:  operation.OpTypeBeforeUpdate,
+			updates: bson.A{setName, bson.D{{Key: "$unset", Value: "age"}}},
+			want:    bson.A{setName, bson.D{{Key: "$unset", Value: "age"}}, updateStage},
+		},
+		{
+			name:   "fields set by the pipeline are kept",
+			opType: operation.OpTypeBeforeUpdate,
+			updates: []bson.D{
+				{{Key: "$replaceWith", Value: "$$ROOT"}},
+				{{Key: "$addFields", Value: bson.M{"updated_at": "$$NOW", "update_milli_time": 1}}},
+				{{Key: "$set", Value: bson.D{{Key: "version", Value: 10}}}},
+			},
+			want: []bson.D{
+				{{Key: "$replaceWith", Value: "$$ROOT"}},
+				{{Key: "$addFields", Value: bson.M{"updated_at": "$$NOW", "update_milli_time": 1}}},
+				{{Key: "$set", Value: bson.D{{Key: "version", Value: 10}}}},
+			},
+		},
+		{
+			name:    "upsert",
+			opType:  operation.OpTypeBeforeUpsert,
+			updates: mongo.Pipeline{setName},
+			want: mongo.Pipeline{setName, bson.D{{Key: "$set", Value: append(updateStage[0].Value.(bson.D),
+				bson.E{Key: "created_at", Value: bson.D{{Key: "$ifNull", Value: bson.A{"$created_at", now}}}},
+			)}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opCtx := operation.NewOpContext(nil, operation.WithUpdates(tc.updates), operation.WithStartTime(now), operation.WithFields(fields))
+			require.NoError(t, Execute(context.Background(), opCtx, tc.opType))
+			assert.Equal(t, tc.want, opCtx.Updates)
+		})
+	}
+}
+
+func TestExecute_pipelineNotModified(t *testing.T) {
+	fields := field.ParseFields(pipelineUser{})
+	updates := mongo.Pipeline{{{Key: "$set", Value: bson.D{{Key: "name", Value: "chenmingyong"}}}}}
+	opCtx := operation.NewOpContext(nil, operation.WithUpdates(updates), operation.WithStartTime(time.Now()), operation.WithFields(fields))
+
+	require.NoError(t, Execute(context.Background(), opCtx, operation.OpTypeBeforeUpdate))
+	// the stage is appended to a copy of the pipeline, so the pipeline of the caller can be reused
+	assert.Len(t, updates, 1)
+	assert.Len(t, opCtx.Updates, 2)
+}
@@ -76,6 +76,10 @@ func setTimeField(dest reflect.Value, timeType field.TimeType, currentTime time.
 	if !dest.IsZero() {
 		return
 	}
+	setTimeValue(dest, timeType, currentTime, fieldType)
+}
+
+func setTimeValue(dest reflect.Value, timeType field.TimeType, currentTime time.Time, fieldType reflect.Type) {
 	switch timeType {
 	case field.UnixTime:
 		dest.Set(reflect.ValueOf(currentTime))
@@ -95,10 +99,18 @@ func setTimeField(dest reflect.Value, timeType field.TimeType, currentTime time.
 
 func beforeUpdate(dest any, currentTime time.Time, fields []*field.Filed, _ ...any) error {
 	updates, exist := dest.(bson.M)
-	if !exist || updates == nil {
+	if !exist {
+		// a replacement document
+		if v := reflect.ValueOf(dest); v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+			processFields4Replace(v.Elem(), currentTime, fields)
+		}
+		return nil
+	}
+	if updates == nil {
 		return nil
 	}
 
+	incVersion(updates, fields)
 	updatedFields := findAdditionalFields(currentTime, fields, findUpdatedFields)
 	if len(updatedFields) > 0 {
 		if updates["$set"] == nil {
@@ -118,12 +130,77 @@ func beforeUpdate(dest any, currentTime time.Time, fields []*field.Filed, _ ...a
 	return nil
 }
 
+// processFields4Replace refreshes the update time fields of the replacement document
+func processFields4Replace(dest reflect.Value, currentTime time.Time, fields []*field.Filed) {
+	for idx, fd := range fields {
+		value := dest.Field(idx)
+		if fd.InlinedFields != nil {
+			processFields4Replace(value, currentTime, fd.InlinedFields)
+		} else if fd.AutoUpdateTime != 0 {
+			setTimeValue(value, fd.AutoUpdateTime, currentTime, fd.FieldType)
+		} else if fd.Version {
+			incVersionValue(value)
+		}
+	}
+}
+
+// incVersion increments the version field by $inc unless the updates already modify it
+func incVersion(updates bson.M, fields []*field.Filed) {
+	versionFields := findAdditionalFields(time.Time{}, fields, findVersionFields)
+	if len(versionFields) == 0 {
+		return
+	}
+	for _, op := range []string{"$set", "$inc"} {
+		if opFields, ok := updates[op].(bson.M); ok {
+			for k := range versionFields {
+				if _, exist := opFields[k]; exist {
+					delete(versionFields, k)
+				}
+			}
+		}
+	}
+	if len(versionFields) == 0 {
+		return
+	}
+
+	if updates["$inc"] == nil {
+		updates["$inc"] = bson.M{}
+	}
+	if incFields, ok := updates["$inc"].(bson.M); ok {
+		for k, v := range versionFields {
+			incFields[k] = v
+		}
+	}
+}
+
+// incVersionValue increments the version of the replacement document
+func incVersionValue(dest reflect.Value) {
+	switch dest.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		dest.SetInt(dest.Int() + 1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		dest.SetUint(dest.Uint() + 1)
+	default:
+	}
+}
+
 func beforeUpsert(dest any, currentTime time.Time, fields []*field.Filed, _ ...any) error {
 	updates, exist := dest.(bson.M)
-	if !exist || updates == nil {
+	if !exist {
+		// a replacement document, which is inserted if no document matches
+		if v := reflect.ValueOf(dest); v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+			if err := processFields4Insert(v.Elem(), currentTime, fields); err != nil {
+				return err
+			}
+			processFields4Replace(v.Elem(), currentTime, fields)
+		}
+		return nil
+	}
+	if updates == nil {
 		return nil
 	}
 
+	incVersion(updates, fields)
 	updatedTimes := findAdditionalFields(currentTime, fields, findUpdatedFields)
 
 	if len(updatedTimes) > 0 {
@@ -195,6 +272,13 @@ func findUpdatedFields(fd *field.Filed, currentTime time.Time) (string, any) {
 	return "", nil
 }
 
+func findVersionFields(fd *field.Filed, _ time.Time) (string, any) {
+	if fd.Version {
+		return fd.MongoField, 1
+	}
+	return "", nil
+}
+
 func getTimeValue(timeType field.TimeType, currentTime time.Time) any {
 	switch timeType {
 	case field.UnixTime:
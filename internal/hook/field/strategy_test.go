@@ -218,6 +218,11 @@ func TestBeforeInsert(t *testing.T) {
 	}
 }
 
+type versionedUser struct {
+	Name    string `bson:"name"`
+	Version int64  `bson:"version" mongox:"version"`
+}
+
 func Test_beforeUpdate(t *testing.T) {
 
 	tests := []struct {
@@ -304,6 +309,49 @@ func Test_beforeUpdate(t *testing.T) {
 			}{}),
 			want: bson.M{"$set": bson.M{"name": "Mingyong Chen", "updated_at": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Unix()}},
 		},
+		{
+			name:        "a replacement document",
+			updates:     &inlinedUser{model: model{CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, Name: "Mingyong Chen", UpdateSecondTime: 1},
+			currentTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			fields:      field.ParseFields(&inlinedUser{}),
+			want: &inlinedUser{
+				model:            model{CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+				Name:             "Mingyong Chen",
+				UpdateSecondTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Unix(),
+				UpdateMilliTime:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli(),
+				UpdateNanoTime:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano(),
+			},
+		},
+		{
+			name:    "a bson.M updates with version field",
+			updates: bson.M{"$set": bson.M{"name": "Mingyong Chen"}},
+			fields:  field.ParseFields(&versionedUser{}),
+			want:    bson.M{"$set": bson.M{"name": "Mingyong Chen"}, "$inc": bson.M{"version": 1}},
+		},
+		{
+			name:    "a bson.M updates modifying version field",
+			updates: bson.M{"$set": bson.M{"name": "Mingyong Chen"}, "$inc": bson.M{"version": 2}},
+			fields:  field.ParseFields(&versionedUser{}),
+			want:    bson.M{"$set": bson.M{"name": "Mingyong Chen"}, "$inc": bson.M{"version": 2}},
+		},
+		{
+			name:    "a bson.M updates setting version field",
+			updates: bson.M{"$set": bson.M{"name": "Mingyong Chen", "version": 0}},
+			fields:  field.ParseFields(&versionedUser{}),
+			want:    bson.M{"$set": bson.M{"name": "Mingyong Chen", "version": 0}},
+		},
+		{
+			name:    "a replacement document with version field",
+			updates: &versionedUser{Name: "Mingyong Chen", Version: 3},
+			fields:  field.ParseFields(&versionedUser{}),
+			want:    &versionedUser{Name: "Mingyong Chen", Version: 4},
+		},
+		{
+			name:    "a nil replacement document",
+			updates: (*inlinedUser)(nil),
+			fields:  field.ParseFields(&inlinedUser{}),
+			want:    (*inlinedUser)(nil),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -314,6 +362,12 @@ func Test_beforeUpdate(t *testing.T) {
 	}
 }
 
+type versionedModelUser struct {
+	model   `bson:",inline"`
+	Name    string `bson:"name"`
+	Version int64  `bson:"version" mongox:"version"`
+}
+
 func Test_beforeUpsert(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -382,6 +436,12 @@ func Test_beforeUpsert(t *testing.T) {
 			fields:      field.ParseFields(&inlinedUpdatedUser{}),
 			want:        bson.M{"$set": bson.M{"name": "Mingyong Chen", "updated_at": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "update_second_time": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Unix(), "update_milli_time": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli(), "update_nano_time": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()}, "$setOnInsert": bson.M{"created_at": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "create_second_time": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Unix(), "create_milli_time": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli(), "create_nano_time": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()}},
 		},
+		{
+			name:    "a bson.M updates with version field",
+			updates: bson.M{"$set": bson.M{"name": "Mingyong Chen"}},
+			fields:  field.ParseFields(&versionedUser{}),
+			want:    bson.M{"$set": bson.M{"name": "Mingyong Chen"}, "$inc": bson.M{"version": 1}},
+		},
 		{
 			name:        "a bson.M updates and not time.Time type for default time field",
 			updates:     bson.M{"$set": bson.M{"name": "Mingyong Chen"}},
@@ -392,6 +452,17 @@ func Test_beforeUpsert(t *testing.T) {
 			}{}),
 			want: bson.M{"$set": bson.M{"name": "Mingyong Chen", "updated_at": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Unix()}, "$setOnInsert": bson.M{"created_at": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Unix()}},
 		},
+		{
+			name:        "a replacement document",
+			updates:     &versionedModelUser{model: model{ID: bson.ObjectID{1}, UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, Name: "Mingyong Chen", Version: 1},
+			currentTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			fields:      field.ParseFields(&versionedModelUser{}),
+			want: &versionedModelUser{
+				model:   model{ID: bson.ObjectID{1}, CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+				Name:    "Mingyong Chen",
+				Version: 2,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -30,7 +30,8 @@ func getPayload(opCtx *operation.OpContext, opType operation.OpType) any {
 	}
 
 	switch opType {
-	case operation.OpTypeBeforeInsert, operation.OpTypeAfterInsert, operation.OpTypeAfterFind:
+	// the documents of a deletion are only known when they are found before being deleted
+	case operation.OpTypeBeforeInsert, operation.OpTypeAfterInsert, operation.OpTypeAfterFind, operation.OpTypeBeforeDelete, operation.OpTypeAfterDelete:
 		return opCtx.Doc
 	case operation.OpTypeBeforeUpdate, operation.OpTypeAfterUpdate, operation.OpTypeBeforeUpsert, operation.OpTypeAfterUpsert:
 		return opCtx.Updates
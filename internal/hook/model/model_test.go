@@ -157,6 +157,18 @@ func Test_getPayload(t *testing.T) {
 			opType: operation.OpTypeAfterDelete,
 			want:   &entity{},
 		},
+		{
+			name:   "before delete with docs",
+			opCtx:  operation.NewOpContext(nil, operation.WithDoc([]*entity{{}})),
+			opType: operation.OpTypeBeforeDelete,
+			want:   []*entity{{}},
+		},
+		{
+			name:   "after delete without docs and model hook",
+			opCtx:  operation.NewOpContext(nil),
+			opType: operation.OpTypeAfterDelete,
+			want:   nil,
+		},
 		{
 			name:   "before update",
 			opCtx:  operation.NewOpContext(nil, operation.WithUpdates(&entity{})),
@@ -0,0 +1,246 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// Models returns the index models declared by the mongox tags of the fields.
+// The fields declaring the same index name with the compound tag share one compound index,
+// which is placed where its first field is declared. The server only expires documents by a single field index,
+// so a ttl declared on a field of a compound index is reported as an error,
+// as well as the invalid index tags and an index name declared by more than one field without the compound tag.
+func Models(fields []*field.Filed) ([]mongo.IndexModel, error) {
+	var (
+		models []mongo.IndexModel
+		// the positions of the named indexes in models, and whether they are compound
+		named    = make(map[string]int)
+		compound = make(map[string]bool)
+	)
+	for _, fd := range flatten(fields) {
+		idx := fd.Index
+		if idx.Err != nil {
+			return nil, idx.Err
+		}
+		if idx.TTL > 0 && idx.Compound {
+			return nil, fmt.Errorf("mongox: the ttl of %s can't be declared on the compound index %q", fd.MongoField, idx.Name)
+		}
+		if idx.Name != "" {
+			i, ok := named[idx.Name]
+			switch {
+			case ok && (!idx.Compound || !compound[idx.Name]):
+				return nil, fmt.Errorf("mongox: the index %q is declared by more than one field, they must all be tagged with compound", idx.Name)
+			case ok:
+				models[i].Keys = append(models[i].Keys.(bson.D), bson.E{Key: fd.MongoField, Value: 1})
+				if idx.Unique {
+					models[i].Options.SetUnique(true)
+				}
+				continue
+			}
+			named[idx.Name], compound[idx.Name] = len(models), idx.Compound
+		}
+
+		opts := options.Index()
+		if idx.Name != "" {
+			opts.SetName(idx.Name)
+		}
+		if idx.Unique {
+			opts.SetUnique(true)
+		}
+		if idx.TTL > 0 {
+			opts.SetExpireAfterSeconds(int32(idx.TTL.Seconds()))
+		}
+		models = append(models, mongo.IndexModel{Keys: bson.D{{Key: fd.MongoField, Value: 1}}, Options: opts})
+	}
+	return models, nil
+}
+
+// flatten returns the indexed fields, the inlined fields are expanded in place
+func flatten(fields []*field.Filed) []*field.Filed {
+	var indexed []*field.Filed
+	for _, fd := range fields {
+		if fd.InlinedFields != nil {
+			indexed = append(indexed, flatten(fd.InlinedFields)...)
+			continue
+		}
+		if fd.Index != nil {
+			indexed = append(indexed, fd)
+		}
+	}
+	return indexed
+}
+
+// Spec is the part of an index which is compared to find out whether the index in the collection is stale
+type Spec struct {
+	Name               string
+	Keys               bson.Raw
+	Unique             bool
+	ExpireAfterSeconds *int32
+	// PartialFilter is the partialFilterExpression of a partial index, nil for the other indexes
+	PartialFilter bson.Raw
+}
+
+// NewSpec returns the spec of the index model, the name is generated in the same way as the driver if it isn't set
+func NewSpec(model mongo.IndexModel) (*Spec, error) {
+	keys, err := bson.Marshal(model.Keys)
+	if err != nil {
+		return nil, err
+	}
+	args := &options.IndexOptions{}
+	if model.Options != nil {
+		if args, err = utils.MergeOptions[options.IndexOptions](model.Options); err != nil {
+			return nil, err
+		}
+	}
+
+	spec := &Spec{Keys: keys, ExpireAfterSeconds: args.ExpireAfterSeconds}
+	if args.Unique != nil {
+		spec.Unique = *args.Unique
+	}
+	if args.PartialFilterExpression != nil {
+		if spec.PartialFilter, err = bson.Marshal(args.PartialFilterExpression); err != nil {
+			return nil, err
+		}
+	}
+	if args.Name != nil {
+		spec.Name = *args.Name
+	} else if spec.Name, err = Name(keys); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// listedIndex is the index returned by listIndexes,
+// mongo.IndexSpecification is not used since it leaves out the partialFilterExpression
+type listedIndex struct {
+	Name                    string   `bson:"name"`
+	Keys                    bson.Raw `bson:"key"`
+	Unique                  *bool    `bson:"unique"`
+	ExpireAfterSeconds      *int32   `bson:"expireAfterSeconds"`
+	PartialFilterExpression bson.Raw `bson:"partialFilterExpression"`
+}
+
+// SpecOf returns the spec of the index in the collection, which is a document returned by listIndexes
+func SpecOf(raw bson.Raw) (*Spec, error) {
+	var listed listedIndex
+	if err := bson.Unmarshal(raw, &listed); err != nil {
+		return nil, err
+	}
+	spec := &Spec{
+		Name:               listed.Name,
+		Keys:               listed.Keys,
+		ExpireAfterSeconds: listed.ExpireAfterSeconds,
+		PartialFilter:      listed.PartialFilterExpression,
+	}
+	if listed.Unique != nil {
+		spec.Unique = *listed.Unique
+	}
+	return spec, nil
+}
+
+// Name generates the index name from the keys like the driver does, e.g. {age: 1, name: -1} -> age_1_name_-1
+func Name(keys bson.Raw) (string, error) {
+	elems, err := keys.Elements()
+	if err != nil {
+		return "", err
+	}
+	parts := make([]string, 0, len(elems)*2)
+	for _, elem := range elems {
+		value := elem.Value()
+		var v string
+		switch value.Type {
+		case bson.TypeInt32:
+			v = fmt.Sprintf("%d", value.Int32())
+		case bson.TypeInt64:
+			v = fmt.Sprintf("%d", value.Int64())
+		case bson.TypeString:
+			v = value.StringValue()
+		default:
+			return "", mongo.ErrInvalidIndexValue
+		}
+		parts = append(parts, elem.Key(), v)
+	}
+	return strings.Join(parts, "_"), nil
+}
+
+// Equal reports whether the two specs describe the same index
+func (s *Spec) Equal(other *Spec) bool {
+	if s.Name != other.Name || s.Unique != other.Unique || !s.SameKeys(other) {
+		return false
+	}
+	if len(s.PartialFilter) == 0 || len(other.PartialFilter) == 0 {
+		if len(s.PartialFilter) != len(other.PartialFilter) {
+			return false
+		}
+	} else if !sameDocument(s.PartialFilter, other.PartialFilter) {
+		return false
+	}
+	if s.ExpireAfterSeconds == nil || other.ExpireAfterSeconds == nil {
+		return s.ExpireAfterSeconds == other.ExpireAfterSeconds
+	}
+	return *s.ExpireAfterSeconds == *other.ExpireAfterSeconds
+}
+
+// SameKeys reports whether the two specs have the same keys in the same order.
+// The numeric directions are compared by value because the server may return them in a different numeric type.
+func (s *Spec) SameKeys(other *Spec) bool {
+	return sameDocument(s.Keys, other.Keys)
+}
+
+// sameDocument reports whether the two documents have the same elements in the same order,
+// the numbers are compared by value and the embedded documents and arrays are compared in the same way
+func sameDocument(a, b bson.Raw) bool {
+	aElems, err := a.Elements()
+	if err != nil {
+		return false
+	}
+	bElems, err := b.Elements()
+	if err != nil || len(aElems) != len(bElems) {
+		return false
+	}
+	for i := range aElems {
+		if aElems[i].Key() != bElems[i].Key() || !sameValue(aElems[i].Value(), bElems[i].Value()) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameValue(a, b bson.RawValue) bool {
+	an, aok := a.AsFloat64OK()
+	bn, bok := b.AsFloat64OK()
+	if aok && bok {
+		return an == bn
+	}
+	if a.Type != b.Type {
+		return false
+	}
+	switch a.Type {
+	case bson.TypeEmbeddedDocument:
+		return sameDocument(a.Document(), b.Document())
+	case bson.TypeArray:
+		return sameDocument(bson.Raw(a.Array()), bson.Raw(b.Array()))
+	default:
+		return a.Equal(b)
+	}
+}
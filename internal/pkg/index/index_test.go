@@ -0,0 +1,270 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type base struct {
+	ID       bson.ObjectID `bson:"_id,omitempty"`
+	TenantID string        `bson:"tenant_id" mongox:"index:idx_tenant_name,compound"`
+}
+
+func specs(t *testing.T, models []mongo.IndexModel) []*Spec {
+	result := make([]*Spec, 0, len(models))
+	for _, model := range models {
+		spec, err := NewSpec(model)
+		require.NoError(t, err)
+		result = append(result, spec)
+	}
+	return result
+}
+
+func rawKeys(t *testing.T, keys bson.D) bson.Raw {
+	raw, err := bson.Marshal(keys)
+	require.NoError(t, err)
+	return raw
+}
+
+func TestModels(t *testing.T) {
+	ttl := int32(86400)
+	testCases := []struct {
+		name string
+		doc  any
+		want []*Spec
+	}{
+		{
+			name: "no index",
+			doc: struct {
+				Name string `bson:"name"`
+			}{},
+			want: []*Spec{},
+		},
+		{
+			name: "single field indexes",
+			doc: struct {
+				Name     string    `bson:"name" mongox:"index"`
+				Email    string    `bson:"email" mongox:"unique:uniq_email"`
+				ExpireAt time.Time `bson:"expire_at" mongox:"ttl:24h"`
+			}{},
+			want: []*Spec{
+				{Name: "name_1", Keys: rawKeys(t, bson.D{{Key: "name", Value: 1}})},
+				{Name: "uniq_email", Keys: rawKeys(t, bson.D{{Key: "email", Value: 1}}), Unique: true},
+				{Name: "expire_at_1", Keys: rawKeys(t, bson.D{{Key: "expire_at", Value: 1}}), ExpireAfterSeconds: &ttl},
+			},
+		},
+		{
+			name: "compound index with inlined fields",
+			doc: struct {
+				base `bson:",inline"`
+				Age  int    `bson:"age" mongox:"index"`
+				Name string `bson:"name" mongox:"unique:idx_tenant_name,compound"`
+			}{},
+			want: []*Spec{
+				{Name: "idx_tenant_name", Keys: rawKeys(t, bson.D{{Key: "tenant_id", Value: 1}, {Key: "name", Value: 1}}), Unique: true},
+				{Name: "age_1", Keys: rawKeys(t, bson.D{{Key: "age", Value: 1}})},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			models, err := Models(field.ParseFields(tc.doc))
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, specs(t, models))
+		})
+	}
+}
+
+func TestModels_CompoundTTL(t *testing.T) {
+	_, err := Models(field.ParseFields(struct {
+		TenantID string    `bson:"tenant_id" mongox:"index:idx_tenant_expire,compound"`
+		ExpireAt time.Time `bson:"expire_at" mongox:"index:idx_tenant_expire,compound,ttl:24h"`
+	}{}))
+	assert.EqualError(t, err, `mongox: the ttl of expire_at can't be declared on the compound index "idx_tenant_expire"`)
+}
+
+func TestModels_InvalidTTL(t *testing.T) {
+	_, err := Models(field.ParseFields(struct {
+		ExpireAt time.Time `bson:"expire_at" mongox:"ttl:7d"`
+	}{}))
+	assert.EqualError(t, err, `mongox: invalid ttl of ExpireAt: time: unknown unit "d" in duration "7d"`)
+}
+
+func TestModels_DuplicateName(t *testing.T) {
+	testCases := []struct {
+		name string
+		doc  any
+	}{
+		{
+			name: "none compound",
+			doc: struct {
+				Name string `bson:"name" mongox:"index:idx_user"`
+				Age  int    `bson:"age" mongox:"index:idx_user"`
+			}{},
+		},
+		{
+			name: "compound after none compound",
+			doc: struct {
+				Name string `bson:"name" mongox:"index:idx_user"`
+				Age  int    `bson:"age" mongox:"index:idx_user,compound"`
+			}{},
+		},
+		{
+			name: "none compound after compound",
+			doc: struct {
+				Name string `bson:"name" mongox:"index:idx_user,compound"`
+				Age  int    `bson:"age" mongox:"unique:idx_user"`
+			}{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Models(field.ParseFields(tc.doc))
+			assert.EqualError(t, err, `mongox: the index "idx_user" is declared by more than one field, they must all be tagged with compound`)
+		})
+	}
+}
+
+func TestName(t *testing.T) {
+	name, err := Name(rawKeys(t, bson.D{{Key: "age", Value: int32(1)}, {Key: "name", Value: int64(-1)}, {Key: "bio", Value: "text"}}))
+	require.NoError(t, err)
+	assert.Equal(t, "age_1_name_-1_bio_text", name)
+
+	_, err = Name(rawKeys(t, bson.D{{Key: "age", Value: 1.5}}))
+	assert.Equal(t, mongo.ErrInvalidIndexValue, err)
+}
+
+func TestNewSpec(t *testing.T) {
+	spec, err := NewSpec(mongo.IndexModel{Keys: bson.D{{Key: "age", Value: -1}}})
+	require.NoError(t, err)
+	assert.Equal(t, &Spec{Name: "age_-1", Keys: rawKeys(t, bson.D{{Key: "age", Value: -1}})}, spec)
+
+	spec, err = NewSpec(mongo.IndexModel{Keys: bson.D{{Key: "age", Value: 1}}, Options: options.Index().SetPartialFilterExpression(bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: 18}}}})})
+	require.NoError(t, err)
+	assert.Equal(t, rawKeys(t, bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: 18}}}}), spec.PartialFilter)
+
+	_, err = NewSpec(mongo.IndexModel{Keys: 1})
+	assert.Error(t, err)
+}
+
+func TestSpecOf(t *testing.T) {
+	unique, ttl := true, int32(60)
+	raw := rawKeys(t, bson.D{
+		{Key: "v", Value: 2},
+		{Key: "key", Value: bson.D{{Key: "age", Value: 1}}},
+		{Key: "name", Value: "age_1"},
+		{Key: "unique", Value: unique},
+		{Key: "expireAfterSeconds", Value: ttl},
+		{Key: "partialFilterExpression", Value: bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: 18}}}}},
+	})
+	spec, err := SpecOf(raw)
+	require.NoError(t, err)
+	assert.Equal(t, &Spec{
+		Name:               "age_1",
+		Keys:               rawKeys(t, bson.D{{Key: "age", Value: 1}}),
+		Unique:             true,
+		ExpireAfterSeconds: &ttl,
+		PartialFilter:      rawKeys(t, bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: 18}}}}),
+	}, spec)
+
+	_, err = SpecOf(bson.Raw{0})
+	assert.Error(t, err)
+}
+
+func TestSpec_Equal(t *testing.T) {
+	ttl, otherTTL := int32(60), int32(60)
+	spec := &Spec{Name: "age_1", Keys: rawKeys(t, bson.D{{Key: "age", Value: int32(1)}}), ExpireAfterSeconds: &ttl}
+	testCases := []struct {
+		name  string
+		other *Spec
+		want  bool
+	}{
+		{
+			name:  "equal with different numeric types",
+			other: &Spec{Name: "age_1", Keys: rawKeys(t, bson.D{{Key: "age", Value: 1.0}}), ExpireAfterSeconds: &otherTTL},
+			want:  true,
+		},
+		{
+			name:  "different name",
+			other: &Spec{Name: "idx_age", Keys: spec.Keys, ExpireAfterSeconds: &ttl},
+		},
+		{
+			name:  "different unique",
+			other: &Spec{Name: "age_1", Keys: spec.Keys, Unique: true, ExpireAfterSeconds: &ttl},
+		},
+		{
+			name:  "different keys",
+			other: &Spec{Name: "age_1", Keys: rawKeys(t, bson.D{{Key: "age", Value: -1}}), ExpireAfterSeconds: &ttl},
+		},
+		{
+			name:  "different ttl",
+			other: &Spec{Name: "age_1", Keys: spec.Keys},
+		},
+		{
+			name:  "partial filter added",
+			other: &Spec{Name: "age_1", Keys: spec.Keys, ExpireAfterSeconds: &ttl, PartialFilter: rawKeys(t, bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: 18}}}})},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, spec.Equal(tc.other))
+		})
+	}
+}
+
+func TestSpec_Equal_PartialFilter(t *testing.T) {
+	partial := func(filter bson.D) *Spec {
+		return &Spec{Name: "age_1", Keys: rawKeys(t, bson.D{{Key: "age", Value: 1}}), PartialFilter: rawKeys(t, filter)}
+	}
+	spec := partial(bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: 18}}}, {Key: "tags", Value: bson.D{{Key: "$in", Value: bson.A{"a", int32(1)}}}}})
+
+	// the numbers returned by the server may have another type
+	assert.True(t, spec.Equal(partial(bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: int64(18)}}}, {Key: "tags", Value: bson.D{{Key: "$in", Value: bson.A{"a", 1.0}}}}})))
+	assert.False(t, spec.Equal(partial(bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: 20}}}, {Key: "tags", Value: bson.D{{Key: "$in", Value: bson.A{"a", 1}}}}})))
+	assert.False(t, spec.Equal(partial(bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: 18}}}, {Key: "tags", Value: bson.D{{Key: "$in", Value: bson.A{"b", 1}}}}})))
+	assert.False(t, spec.Equal(partial(bson.D{{Key: "age", Value: bson.D{{Key: "$gt", Value: 18}}}})))
+	assert.False(t, spec.Equal(&Spec{Name: "age_1", Keys: spec.Keys}))
+}
+
+func TestSpec_SameKeys(t *testing.T) {
+	spec := &Spec{Keys: rawKeys(t, bson.D{{Key: "age", Value: 1}, {Key: "bio", Value: "text"}})}
+	assert.True(t, spec.SameKeys(&Spec{Keys: rawKeys(t, bson.D{{Key: "age", Value: int64(1)}, {Key: "bio", Value: "text"}})}))
+	assert.False(t, spec.SameKeys(&Spec{Keys: rawKeys(t, bson.D{{Key: "bio", Value: "text"}, {Key: "age", Value: 1}})}))
+	assert.False(t, spec.SameKeys(&Spec{Keys: rawKeys(t, bson.D{{Key: "age", Value: 1}})}))
+	assert.False(t, spec.SameKeys(&Spec{Keys: rawKeys(t, bson.D{{Key: "age", Value: 1}, {Key: "bio", Value: "2d"}})}))
+}
+
+func TestModels_Options(t *testing.T) {
+	models, err := Models(field.ParseFields(struct {
+		Name string `bson:"name" mongox:"index:idx_name"`
+	}{}))
+	require.NoError(t, err)
+	require.Len(t, models, 1)
+	args := &options.IndexOptions{}
+	for _, set := range models[0].Options.List() {
+		require.NoError(t, set(args))
+	}
+	assert.Equal(t, "idx_name", *args.Name)
+	assert.Nil(t, args.Unique)
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replace
+
+import (
+	"reflect"
+
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Kept returns the mongo names of the _id and the create time fields which are zero in the replacement, a pointer to a struct.
+// These fields keep the values of the replaced document instead of being overwritten by the zero values.
+func Kept(fields []*field.Filed, replacement any) []string {
+	v := reflect.ValueOf(replacement)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	var kept []string
+	walk(v.Elem(), fields, func(fd *field.Filed, value reflect.Value) {
+		if value.IsZero() {
+			kept = append(kept, fd.MongoField)
+		}
+	})
+	return kept
+}
+
+// Pipeline returns the update pipeline which replaces the document with the replacement in a single write,
+// except that the kept fields keep their stored values. The values of the kept fields in the replacement
+// are only stored if the document doesn't have them, such as when the replacement is upserted,
+// and the zero values are never stored, so that the _id of an upserted document is generated by the server.
+func Pipeline(fields []*field.Filed, replacement any, kept []string) (mongo.Pipeline, error) {
+	data, err := bson.Marshal(replacement)
+	if err != nil {
+		return nil, err
+	}
+	elements, err := bson.Raw(data).Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	zero := make(map[string]bool, len(kept))
+	if v := reflect.ValueOf(replacement); v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+		walk(v.Elem(), fields, func(fd *field.Filed, value reflect.Value) {
+			zero[fd.MongoField] = value.IsZero()
+		})
+	}
+	isKept := make(map[string]bool, len(kept))
+	for _, key := range kept {
+		isKept[key] = true
+	}
+
+	doc := make(bson.D, 0, len(elements)+len(kept))
+	// the kept fields left out of the replacement, such as a zero _id tagged with omitempty
+	for _, key := range kept {
+		if _, err := bson.Raw(data).LookupErr(key); err != nil {
+			doc = append(doc, bson.E{Key: key, Value: "$" + key})
+		}
+	}
+	for _, element := range elements {
+		key := element.Key()
+		switch {
+		case !isKept[key]:
+			doc = append(doc, bson.E{Key: key, Value: bson.D{{Key: "$literal", Value: element.Value()}}})
+		case zero[key]:
+			doc = append(doc, bson.E{Key: key, Value: "$" + key})
+		default:
+			doc = append(doc, bson.E{Key: key, Value: bson.D{{Key: "$ifNull", Value: bson.A{"$" + key, bson.D{{Key: "$literal", Value: element.Value()}}}}}})
+		}
+	}
+	return mongo.Pipeline{{{Key: "$replaceWith", Value: doc}}}, nil
+}
+
+// walk calls fn with the _id and the create time fields of the struct, the fields of the inlined structs included
+func walk(dest reflect.Value, fields []*field.Filed, fn func(fd *field.Filed, value reflect.Value)) {
+	for idx, fd := range fields {
+		if fd.InlinedFields != nil {
+			walk(dest.Field(idx), fd.InlinedFields, fn)
+		} else if fd.MongoField == "_id" || fd.AutoCreateTime != 0 {
+			fn(fd, dest.Field(idx))
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replace
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type ReplaceModel struct {
+	ID        bson.ObjectID `bson:"_id,omitempty"`
+	CreatedAt time.Time     `bson:"created_at"`
+	UpdatedAt time.Time     `bson:"updated_at"`
+}
+
+type replaceUser struct {
+	ReplaceModel `bson:",inline"`
+	Name         string `bson:"name"`
+	CreateTime   int64  `bson:"create_time" mongox:"autoCreateTime:milli"`
+}
+
+func TestKept(t *testing.T) {
+	fields := field.ParseFields(replaceUser{})
+
+	assert.Equal(t, []string{"_id", "created_at", "create_time"}, Kept(fields, &replaceUser{Name: "cmy"}))
+	assert.Equal(t, []string{"created_at"}, Kept(fields, &replaceUser{ReplaceModel: ReplaceModel{ID: bson.NewObjectID()}, CreateTime: 1}))
+	assert.Nil(t, Kept(fields, &replaceUser{ReplaceModel: ReplaceModel{ID: bson.NewObjectID(), CreatedAt: time.Now()}, CreateTime: 1}))
+	assert.Nil(t, Kept(fields, replaceUser{}))
+	assert.Nil(t, Kept(fields, (*replaceUser)(nil)))
+}
+
+func TestPipeline(t *testing.T) {
+	fields := field.ParseFields(replaceUser{})
+	updatedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	literal := func(v any) bson.D {
+		return bson.D{{Key: "$literal", Value: v}}
+	}
+	// the pipelines are compared as extended JSON since the values are raw
+	assertPipeline := func(t *testing.T, want, got mongo.Pipeline) {
+		wantData, err := bson.MarshalExtJSON(bson.D{{Key: "pipeline", Value: want}}, true, false)
+		require.NoError(t, err)
+		gotData, err := bson.MarshalExtJSON(bson.D{{Key: "pipeline", Value: got}}, true, false)
+		require.NoError(t, err)
+		assert.Equal(t, string(wantData), string(gotData))
+	}
+
+	t.Run("zero fields keep the stored values", func(t *testing.T) {
+		replacement := &replaceUser{ReplaceModel: ReplaceModel{UpdatedAt: updatedAt}, Name: "$name"}
+		pipeline, err := Pipeline(fields, replacement, Kept(fields, replacement))
+		require.NoError(t, err)
+		assertPipeline(t, mongo.Pipeline{{{Key: "$replaceWith", Value: bson.D{
+			{Key: "_id", Value: "$_id"},
+			{Key: "created_at", Value: "$created_at"},
+			{Key: "updated_at", Value: literal((bson.NewDateTimeFromTime(updatedAt)))},
+			// the values aren't parsed as expressions
+			{Key: "name", Value: literal(("$name"))},
+			{Key: "create_time", Value: "$create_time"},
+		}}}}, pipeline)
+	})
+
+	t.Run("values filled for an upsert are stored if the document doesn't have them", func(t *testing.T) {
+		replacement := &replaceUser{Name: "cmy"}
+		kept := Kept(fields, replacement)
+		id := bson.NewObjectID()
+		replacement.ID, replacement.CreatedAt, replacement.UpdatedAt = id, updatedAt, updatedAt
+
+		pipeline, err := Pipeline(fields, replacement, kept)
+		require.NoError(t, err)
+		ifNull := func(key string, v any) bson.D {
+			return bson.D{{Key: "$ifNull", Value: bson.A{"$" + key, literal((v))}}}
+		}
+		assertPipeline(t, mongo.Pipeline{{{Key: "$replaceWith", Value: bson.D{
+			{Key: "_id", Value: ifNull("_id", id)},
+			{Key: "created_at", Value: ifNull("created_at", bson.NewDateTimeFromTime(updatedAt))},
+			{Key: "updated_at", Value: literal((bson.NewDateTimeFromTime(updatedAt)))},
+			{Key: "name", Value: literal(("cmy"))},
+			{Key: "create_time", Value: "$create_time"},
+		}}}}, pipeline)
+	})
+
+	t.Run("invalid replacement", func(t *testing.T) {
+		_, err := Pipeline(fields, 1, nil)
+		assert.Error(t, err)
+	})
+}
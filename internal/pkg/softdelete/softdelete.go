@@ -0,0 +1,194 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package softdelete
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// stages that must stay at the beginning of a pipeline, so the $match stage is inserted after them
+var firstStages = map[string]struct{}{
+	"$geoNear":      {},
+	"$search":       {},
+	"$searchMeta":   {},
+	"$vectorSearch": {},
+	"$changeStream": {},
+	"$collStats":    {},
+	"$indexStats":   {},
+}
+
+// Field returns the soft delete field of the model, nil if the model doesn't have one.
+func Field(fields []*field.Filed) *field.Filed {
+	for _, fd := range fields {
+		if fd.InlinedFields != nil {
+			if inlined := Field(fd.InlinedFields); inlined != nil {
+				return inlined
+			}
+			continue
+		}
+		if fd.SoftDelete != 0 {
+			return fd
+		}
+	}
+	return nil
+}
+
+// zeroValue returns the value of the soft delete field when the document is not deleted
+func zeroValue(fd *field.Filed) any {
+	if fd.SoftDelete == field.UnixTime {
+		return time.Time{}
+	}
+	return 0
+}
+
+// NotDeleted returns the condition which matches documents that haven't been soft deleted.
+// The field of a live document is either missing, null or the zero value of its type.
+func NotDeleted(fd *field.Filed) bson.E {
+	return bson.E{Key: fd.MongoField, Value: bson.D{{Key: "$in", Value: bson.A{nil, zeroValue(fd)}}}}
+}
+
+// Deleted returns the condition which matches documents that have been soft deleted.
+func Deleted(fd *field.Filed) bson.E {
+	return bson.E{Key: fd.MongoField, Value: bson.D{{Key: "$nin", Value: bson.A{nil, zeroValue(fd)}}}}
+}
+
+// Value returns the value to be stored in the soft delete field when the document is deleted at currentTime.
+func Value(fd *field.Filed, currentTime time.Time) any {
+	switch fd.SoftDelete {
+	case field.UnixTime:
+		return currentTime
+	case field.UnixSecond:
+		if fd.FieldType != nil && fd.FieldType.Kind() == reflect.Int {
+			return int(currentTime.Unix())
+		}
+		return currentTime.Unix()
+	case field.UnixMillisecond:
+		return currentTime.UnixMilli()
+	case field.UnixNanosecond:
+		return currentTime.UnixNano()
+	}
+	return nil
+}
+
+// Updates returns the updates which soft delete the documents at currentTime
+func Updates(fd *field.Filed, currentTime time.Time) bson.M {
+	return bson.M{"$set": bson.M{fd.MongoField: Value(fd, currentTime)}}
+}
+
+// Scope adds cond to the filter without modifying the original one.
+// A nil filter is returned as is, so that the driver still rejects it.
+// If the filter already contains the key of cond, the caller is considered to be
+// filtering on the soft delete field explicitly and the filter is left untouched.
+func Scope(filter any, cond bson.E) any {
+	switch f := filter.(type) {
+	case nil:
+		return nil
+	case bson.D:
+		for _, e := range f {
+			if e.Key == cond.Key {
+				return f
+			}
+		}
+		scoped := make(bson.D, 0, len(f)+1)
+		scoped = append(scoped, f...)
+		return append(scoped, cond)
+	case bson.M:
+		if _, ok := f[cond.Key]; ok {
+			return f
+		}
+		scoped := make(bson.M, len(f)+1)
+		for k, v := range f {
+			scoped[k] = v
+		}
+		scoped[cond.Key] = cond.Value
+		return scoped
+	case map[string]any:
+		if _, ok := f[cond.Key]; ok {
+			return f
+		}
+		scoped := make(bson.M, len(f)+1)
+		for k, v := range f {
+			scoped[k] = v
+		}
+		scoped[cond.Key] = cond.Value
+		return scoped
+	default:
+		return bson.D{{Key: "$and", Value: bson.A{filter, bson.D{cond}}}}
+	}
+}
+
+// ScopeLive excludes the soft deleted documents of the model from the filter,
+// the filter is returned as is if unscoped is true or the model doesn't have a soft delete field
+func ScopeLive(fields []*field.Filed, filter any, unscoped bool) any {
+	if fd := Field(fields); fd != nil && !unscoped {
+		return Scope(filter, NotDeleted(fd))
+	}
+	return filter
+}
+
+// ScopeLivePipeline is the same as ScopeLive, except that the soft deleted documents are excluded from the pipeline
+func ScopeLivePipeline(fields []*field.Filed, pipeline any, unscoped bool) any {
+	if fd := Field(fields); fd != nil && !unscoped {
+		return ScopePipeline(pipeline, NotDeleted(fd))
+	}
+	return pipeline
+}
+
+// ScopePipeline adds a $match stage with cond to the beginning of the pipeline.
+// Nil pipelines and pipelines of unknown types are returned as is.
+func ScopePipeline(pipeline any, cond bson.E) any {
+	match := bson.D{{Key: "$match", Value: bson.D{cond}}}
+	switch p := pipeline.(type) {
+	case mongo.Pipeline:
+		return mongo.Pipeline(insertStage([]bson.D(p), match, identity))
+	case []bson.D:
+		return insertStage(p, match, identity)
+	case bson.A:
+		return bson.A(insertStage([]any(p), any(match), stageOf))
+	case []any:
+		return insertStage(p, any(match), stageOf)
+	default:
+		return pipeline
+	}
+}
+
+func identity(stage bson.D) bson.D {
+	return stage
+}
+
+func stageOf(stage any) bson.D {
+	d, _ := stage.(bson.D)
+	return d
+}
+
+func insertStage[S any](pipeline []S, match S, toD func(S) bson.D) []S {
+	idx := 0
+	if len(pipeline) > 0 {
+		if first := toD(pipeline[0]); len(first) > 0 {
+			if _, ok := firstStages[first[0].Key]; ok {
+				idx = 1
+			}
+		}
+	}
+	scoped := make([]S, 0, len(pipeline)+1)
+	scoped = append(scoped, pipeline[:idx]...)
+	scoped = append(scoped, match)
+	return append(scoped, pipeline[idx:]...)
+}
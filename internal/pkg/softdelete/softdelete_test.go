@@ -0,0 +1,227 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package softdelete
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type model struct {
+	ID        bson.ObjectID `bson:"_id,omitempty"`
+	DeletedAt time.Time     `bson:"deleted_at,omitempty"`
+}
+
+func TestField(t *testing.T) {
+	testCases := []struct {
+		name string
+		doc  any
+		want string
+	}{
+		{
+			name: "without soft delete field",
+			doc: struct {
+				Name string `bson:"name"`
+			}{},
+		},
+		{
+			name: "default soft delete field",
+			doc:  model{},
+			want: "deleted_at",
+		},
+		{
+			name: "inlined soft delete field",
+			doc: struct {
+				model `bson:",inline"`
+				Name  string `bson:"name"`
+			}{},
+			want: "deleted_at",
+		},
+		{
+			name: "soft delete tag",
+			doc: struct {
+				RemovedAt int64 `bson:"removed_at" mongox:"softDelete:milli"`
+			}{},
+			want: "removed_at",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fd := Field(field.ParseFields(tc.doc))
+			if tc.want == "" {
+				assert.Nil(t, fd)
+				return
+			}
+			assert.Equal(t, tc.want, fd.MongoField)
+		})
+	}
+}
+
+func TestNotDeleted(t *testing.T) {
+	assert.Equal(t, bson.E{Key: "deleted_at", Value: bson.D{{Key: "$in", Value: bson.A{nil, time.Time{}}}}}, NotDeleted(&field.Filed{MongoField: "deleted_at", SoftDelete: field.UnixTime}))
+	assert.Equal(t, bson.E{Key: "deleted_at", Value: bson.D{{Key: "$in", Value: bson.A{nil, 0}}}}, NotDeleted(&field.Filed{MongoField: "deleted_at", SoftDelete: field.UnixSecond}))
+	assert.Equal(t, bson.E{Key: "deleted_at", Value: bson.D{{Key: "$nin", Value: bson.A{nil, 0}}}}, Deleted(&field.Filed{MongoField: "deleted_at", SoftDelete: field.UnixMillisecond}))
+}
+
+func TestValue(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, now, Value(&field.Filed{SoftDelete: field.UnixTime}, now))
+	assert.Equal(t, now.Unix(), Value(&field.Filed{SoftDelete: field.UnixSecond, FieldType: reflect.TypeOf(int64(0))}, now))
+	assert.Equal(t, int(now.Unix()), Value(&field.Filed{SoftDelete: field.UnixSecond, FieldType: reflect.TypeOf(0)}, now))
+	assert.Equal(t, now.UnixMilli(), Value(&field.Filed{SoftDelete: field.UnixMillisecond}, now))
+	assert.Equal(t, now.UnixNano(), Value(&field.Filed{SoftDelete: field.UnixNanosecond}, now))
+	assert.Nil(t, Value(&field.Filed{}, now))
+}
+
+func TestScope(t *testing.T) {
+	cond := bson.E{Key: "deleted_at", Value: bson.D{{Key: "$in", Value: bson.A{nil, 0}}}}
+	testCases := []struct {
+		name   string
+		filter any
+		want   any
+	}{
+		{
+			name:   "nil filter",
+			filter: nil,
+			want:   nil,
+		},
+		{
+			name:   "bson.D",
+			filter: bson.D{{Key: "name", Value: "cmy"}},
+			want:   bson.D{{Key: "name", Value: "cmy"}, cond},
+		},
+		{
+			name:   "bson.D with soft delete field",
+			filter: bson.D{{Key: "deleted_at", Value: 1}},
+			want:   bson.D{{Key: "deleted_at", Value: 1}},
+		},
+		{
+			name:   "bson.M",
+			filter: bson.M{"name": "cmy"},
+			want:   bson.M{"name": "cmy", "deleted_at": cond.Value},
+		},
+		{
+			name:   "map",
+			filter: map[string]any{"name": "cmy"},
+			want:   bson.M{"name": "cmy", "deleted_at": cond.Value},
+		},
+		{
+			name:   "bson.M with soft delete field",
+			filter: bson.M{"deleted_at": 1},
+			want:   bson.M{"deleted_at": 1},
+		},
+		{
+			name:   "other types",
+			filter: struct{ Name string }{Name: "cmy"},
+			want:   bson.D{{Key: "$and", Value: bson.A{struct{ Name string }{Name: "cmy"}, bson.D{cond}}}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Scope(tc.filter, cond))
+		})
+	}
+}
+
+func TestScope_NotModifyFilter(t *testing.T) {
+	cond := bson.E{Key: "deleted_at", Value: nil}
+	d := make(bson.D, 1, 2)
+	d[0] = bson.E{Key: "name", Value: "cmy"}
+	Scope(d, cond)
+	assert.Equal(t, bson.E{}, d[:2][1])
+
+	m := bson.M{"name": "cmy"}
+	Scope(m, cond)
+	assert.Equal(t, bson.M{"name": "cmy"}, m)
+}
+
+func TestScopeLive(t *testing.T) {
+	fields := field.ParseFields(model{})
+	notDeleted := bson.E{Key: "deleted_at", Value: bson.D{{Key: "$in", Value: bson.A{nil, time.Time{}}}}}
+	filter := bson.D{{Key: "name", Value: "cmy"}}
+
+	assert.Equal(t, bson.D{{Key: "name", Value: "cmy"}, notDeleted}, ScopeLive(fields, filter, false))
+	assert.Equal(t, filter, ScopeLive(fields, filter, true))
+	assert.Equal(t, filter, ScopeLive(field.ParseFields(struct{ Name string }{}), filter, false))
+
+	sort := bson.D{{Key: "$sort", Value: bson.D{{Key: "age", Value: 1}}}}
+	assert.Equal(t, mongo.Pipeline{{{Key: "$match", Value: bson.D{notDeleted}}}, sort}, ScopeLivePipeline(fields, mongo.Pipeline{sort}, false))
+	assert.Equal(t, mongo.Pipeline{sort}, ScopeLivePipeline(fields, mongo.Pipeline{sort}, true))
+	assert.Equal(t, mongo.Pipeline{sort}, ScopeLivePipeline(nil, mongo.Pipeline{sort}, false))
+}
+
+func TestScopePipeline(t *testing.T) {
+	cond := bson.E{Key: "deleted_at", Value: nil}
+	match := bson.D{{Key: "$match", Value: bson.D{cond}}}
+	sort := bson.D{{Key: "$sort", Value: bson.D{{Key: "age", Value: 1}}}}
+	geoNear := bson.D{{Key: "$geoNear", Value: bson.D{}}}
+	testCases := []struct {
+		name     string
+		pipeline any
+		want     any
+	}{
+		{
+			name:     "nil pipeline",
+			pipeline: nil,
+			want:     nil,
+		},
+		{
+			name:     "mongo.Pipeline",
+			pipeline: mongo.Pipeline{sort},
+			want:     mongo.Pipeline{match, sort},
+		},
+		{
+			name:     "[]bson.D",
+			pipeline: []bson.D{sort},
+			want:     []bson.D{match, sort},
+		},
+		{
+			name:     "bson.A",
+			pipeline: bson.A{sort},
+			want:     bson.A{match, sort},
+		},
+		{
+			name:     "[]any",
+			pipeline: []any{sort},
+			want:     []any{match, sort},
+		},
+		{
+			name:     "stage must be the first one",
+			pipeline: mongo.Pipeline{geoNear, sort},
+			want:     mongo.Pipeline{geoNear, match, sort},
+		},
+		{
+			name:     "unknown type",
+			pipeline: "pipeline",
+			want:     "pipeline",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ScopePipeline(tc.pipeline, cond))
+		})
+	}
+}
+
+func TestUpdates(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, bson.M{"$set": bson.M{"deleted_at": now.UnixMilli()}}, Updates(&field.Filed{MongoField: "deleted_at", SoftDelete: field.UnixMillisecond}, now))
+}
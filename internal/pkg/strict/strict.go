@@ -0,0 +1,568 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package strict checks the keys and values of the filters, sorts, projections and updates
+// against the fields of the model before they are sent to mongo.
+package strict
+
+import (
+	"context"
+	"fmt"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	dateTimeType   = reflect.TypeOf(bson.DateTime(0))
+	decimalType    = reflect.TypeOf(bson.Decimal128{})
+	objectIDType   = reflect.TypeOf(bson.ObjectID{})
+	interfaceType  = reflect.TypeOf((*any)(nil)).Elem()
+	parsedFields   sync.Map
+	skippedFilters = map[string]struct{}{
+		"$expr":       {},
+		"$where":      {},
+		"$text":       {},
+		"$jsonSchema": {},
+		"$comment":    {},
+	}
+)
+
+// Execute is the callback checking the filter, the sort and projection of the options and the updates of the operation,
+// an error wrapping field.ErrUnknownField or field.ErrTypeMismatch is returned for the first invalid key or value
+func Execute(_ context.Context, opCtx *operation.OpContext, _ ...any) error {
+	if opCtx == nil || len(opCtx.Fields) == 0 {
+		return nil
+	}
+	if err := checkFilter(opCtx.Fields, opCtx.Filter); err != nil {
+		return err
+	}
+	if err := checkOptions(opCtx.Fields, opCtx.MongoOptions); err != nil {
+		return err
+	}
+	return checkUpdates(opCtx.Fields, opCtx.Updates)
+}
+
+func checkFilter(fields []*field.Filed, filter any) error {
+	elems, ok := elements(filter)
+	if !ok {
+		return nil
+	}
+	for _, e := range elems {
+		switch {
+		case e.Key == "$and" || e.Key == "$or" || e.Key == "$nor":
+			conds := reflect.ValueOf(e.Value)
+			if conds.Kind() != reflect.Slice && conds.Kind() != reflect.Array {
+				return fmt.Errorf("%w: %s expects an array, got %T", field.ErrTypeMismatch, e.Key, e.Value)
+			}
+			for i := 0; i < conds.Len(); i++ {
+				if err := checkFilter(fields, conds.Index(i).Interface()); err != nil {
+					return err
+				}
+			}
+		case strings.HasPrefix(e.Key, "$"):
+			if _, skipped := skippedFilters[e.Key]; !skipped {
+				return fmt.Errorf("%w: unknown top level operator %s", field.ErrUnknownField, e.Key)
+			}
+		default:
+			typ, err := resolve(fields, e.Key)
+			if err != nil {
+				return err
+			}
+			if err = checkCondition(typ, e.Key, e.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkCondition checks the value of the key in a filter, which is either an operator document or the value to match
+func checkCondition(typ reflect.Type, key string, value any) error {
+	if indirect(typ).Kind() == reflect.Interface {
+		return nil
+	}
+	ops, ok := operators(value)
+	if !ok {
+		if !matches(typ, value) {
+			return mismatch("$eq", key, typ, value)
+		}
+		return nil
+	}
+	for _, op := range ops {
+		if err := checkOperator(typ, key, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkOperator(typ reflect.Type, key string, op bson.E) error {
+	switch op.Key {
+	case "$eq", "$ne", "$gt", "$gte", "$lt", "$lte":
+		if !matches(typ, op.Value) {
+			return mismatch(op.Key, key, typ, op.Value)
+		}
+	case "$in", "$nin", "$all":
+		values, ok := sliceValues(op.Value)
+		if !ok {
+			return fmt.Errorf("%w: %s of %q expects an array, got %T", field.ErrTypeMismatch, op.Key, key, op.Value)
+		}
+		for _, value := range values {
+			if ops, ok := operators(value); ok && op.Key == "$all" {
+				// {$all: [{$elemMatch: ...}]}
+				if err := checkCondition(typ, key, bson.D(ops)); err != nil {
+					return err
+				}
+				continue
+			}
+			if !matches(typ, value) {
+				return mismatch(op.Key, key, typ, value)
+			}
+		}
+	case "$not":
+		if _, ok := op.Value.(bson.Regex); ok {
+			return checkRegex(typ, key, op.Value)
+		}
+		return checkCondition(typ, key, op.Value)
+	case "$regex":
+		return checkRegex(typ, key, op.Value)
+	case "$size":
+		if !isArray(typ) {
+			return mismatch(op.Key, key, typ, op.Value)
+		}
+	case "$mod":
+		if !isNumber(elemOf(typ)) {
+			return mismatch(op.Key, key, typ, op.Value)
+		}
+	case "$elemMatch":
+		if !isArray(typ) {
+			return mismatch(op.Key, key, typ, op.Value)
+		}
+		elem := indirect(typ.Elem())
+		if _, ok := operators(op.Value); ok {
+			return checkCondition(elem, key, op.Value)
+		}
+		if fields := fieldsOf(elem); fields != nil {
+			return checkFilter(fields, op.Value)
+		}
+	}
+	// the other operators, such as $exists, $type and the geospatial ones, accept the fields of any type
+	return nil
+}
+
+func checkRegex(typ reflect.Type, key string, value any) error {
+	if elemOf(typ).Kind() != reflect.String {
+		return mismatch("$regex", key, typ, value)
+	}
+	return nil
+}
+
+func checkOptions(fields []*field.Filed, opts any) error {
+	var sortDoc, projection any
+	switch o := opts.(type) {
+	case []options.Lister[options.FindOptions]:
+		args, err := utils.MergeOptions(o...)
+		if err != nil {
+			return err
+		}
+		sortDoc, projection = args.Sort, args.Projection
+	case []options.Lister[options.FindOneOptions]:
+		args, err := utils.MergeOptions(o...)
+		if err != nil {
+			return err
+		}
+		sortDoc, projection = args.Sort, args.Projection
+	case []options.Lister[options.FindOneAndUpdateOptions]:
+		args, err := utils.MergeOptions(o...)
+		if err != nil {
+			return err
+		}
+		sortDoc, projection = args.Sort, args.Projection
+	case []options.Lister[options.FindOneAndDeleteOptions]:
+		args, err := utils.MergeOptions(o...)
+		if err != nil {
+			return err
+		}
+		sortDoc, projection = args.Sort, args.Projection
+	case []options.Lister[options.FindOneAndReplaceOptions]:
+		args, err := utils.MergeOptions(o...)
+		if err != nil {
+			return err
+		}
+		sortDoc, projection = args.Sort, args.Projection
+	default:
+		return nil
+	}
+	if elems, ok := elements(sortDoc); ok {
+		for _, e := range elems {
+			if _, err := resolve(fields, e.Key); err != nil {
+				return err
+			}
+		}
+	}
+	if elems, ok := elements(projection); ok {
+		for _, e := range elems {
+			if _, err := resolve(fields, strings.TrimSuffix(e.Key, ".$")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkUpdates(fields []*field.Filed, updates any) error {
+	elems, ok := elements(updates)
+	// the pipelines and the replacements are not checked
+	if !ok || len(elems) == 0 || !strings.HasPrefix(elems[0].Key, "$") {
+		return nil
+	}
+	for _, op := range elems {
+		values, ok := elements(op.Value)
+		if !ok {
+			return fmt.Errorf("%w: %s expects a document, got %T", field.ErrTypeMismatch, op.Key, op.Value)
+		}
+		for _, e := range values {
+			typ, err := resolve(fields, e.Key)
+			if err != nil {
+				return err
+			}
+			if err = checkUpdate(fields, typ, op.Key, e); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkUpdate(fields []*field.Filed, typ reflect.Type, op string, e bson.E) error {
+	if op != "$rename" && indirect(typ).Kind() == reflect.Interface {
+		return nil
+	}
+	switch op {
+	case "$set", "$setOnInsert", "$min", "$max":
+		if !assignable(typ, e.Value) {
+			return mismatch(op, e.Key, typ, e.Value)
+		}
+	case "$inc", "$mul":
+		if !isNumber(typ) {
+			return mismatch(op, e.Key, typ, e.Value)
+		}
+		if e.Value != nil && !isNumber(reflect.TypeOf(e.Value)) {
+			return mismatch(op, e.Key, typ, e.Value)
+		}
+	case "$currentDate":
+		if t := indirect(typ); t != timeType && t != dateTimeType {
+			return mismatch(op, e.Key, typ, e.Value)
+		}
+	case "$rename":
+		target, ok := e.Value.(string)
+		if !ok {
+			return mismatch(op, e.Key, typ, e.Value)
+		}
+		if _, err := resolve(fields, target); err != nil {
+			return err
+		}
+	case "$push", "$addToSet":
+		if !isArray(typ) {
+			return mismatch(op, e.Key, typ, e.Value)
+		}
+		value := e.Value
+		if mods, ok := operators(e.Value); ok {
+			value = nil
+			for _, mod := range mods {
+				if mod.Key == "$each" {
+					value = mod.Value
+				}
+			}
+			if !assignable(typ, value) {
+				return mismatch(op, e.Key, typ, value)
+			}
+			return nil
+		}
+		if !assignable(typ.Elem(), value) {
+			return mismatch(op, e.Key, typ, value)
+		}
+	case "$pullAll":
+		if !isArray(typ) || !assignable(typ, e.Value) {
+			return mismatch(op, e.Key, typ, e.Value)
+		}
+	case "$pop":
+		if !isArray(typ) {
+			return mismatch(op, e.Key, typ, e.Value)
+		}
+	case "$pull":
+		if !isArray(typ) {
+			return mismatch(op, e.Key, typ, e.Value)
+		}
+		elem := indirect(typ.Elem())
+		if _, ok := operators(e.Value); ok {
+			return checkCondition(elem, e.Key, e.Value)
+		}
+		if fields := fieldsOf(elem); fields != nil {
+			if _, ok := elements(e.Value); ok {
+				return checkFilter(fields, e.Value)
+			}
+		}
+		if !matches(elem, e.Value) {
+			return mismatch(op, e.Key, typ, e.Value)
+		}
+	}
+	// $unset and the unknown operators only need the key to be a field of the model
+	return nil
+}
+
+// resolve returns the type of the field referred by the mongo path
+func resolve(fields []*field.Filed, path string) (reflect.Type, error) {
+	segments := strings.Split(path, ".")
+	var typ reflect.Type
+	for i, segment := range segments {
+		if i == 0 {
+			if segment == "_id" {
+				typ = interfaceType
+			}
+			if fd := lookup(fields, segment); fd != nil {
+				typ = fd.FieldType
+			} else if typ == nil {
+				return nil, fmt.Errorf("%w: %s", field.ErrUnknownField, path)
+			}
+			continue
+		}
+		typ = indirect(typ)
+		if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+			if isArrayElement(segment) {
+				typ = typ.Elem()
+				continue
+			}
+			// the path refers to the field of every element of the array
+			typ = indirect(typ.Elem())
+		}
+		switch {
+		case typ.Kind() == reflect.Interface:
+			return interfaceType, nil
+		case typ.Kind() == reflect.Map:
+			typ = typ.Elem()
+		case typ.Kind() == reflect.Struct && typ != timeType && typ != decimalType:
+			fd := lookup(fieldsOf(typ), segment)
+			if fd == nil {
+				return nil, fmt.Errorf("%w: %s", field.ErrUnknownField, path)
+			}
+			typ = fd.FieldType
+		default:
+			return nil, fmt.Errorf("%w: %s", field.ErrUnknownField, path)
+		}
+	}
+	return typ, nil
+}
+
+// lookup finds the exported field by its mongo field name, the fields of the inlined structs are looked up as well.
+// The untagged fields are lower-cased by the driver, so both the Go name and its lower case are accepted.
+func lookup(fields []*field.Filed, key string) *field.Filed {
+	for _, fd := range fields {
+		if fd.InlinedFields != nil {
+			if inlined := lookup(fd.InlinedFields, key); inlined != nil {
+				return inlined
+			}
+			continue
+		}
+		if !token.IsExported(fd.Name) || fd.MongoField == "-" {
+			continue
+		}
+		if fd.MongoField == key || (fd.MongoField == fd.Name && strings.ToLower(fd.Name) == key) {
+			return fd
+		}
+	}
+	return nil
+}
+
+func fieldsOf(typ reflect.Type) []*field.Filed {
+	typ = indirect(typ)
+	if typ.Kind() != reflect.Struct || typ == timeType || typ == decimalType {
+		return nil
+	}
+	if fields, ok := parsedFields.Load(typ); ok {
+		return fields.([]*field.Filed)
+	}
+	fields := field.ParseFields(reflect.New(typ).Interface())
+	parsedFields.Store(typ, fields)
+	return fields
+}
+
+// matches reports whether the value can be matched against the field, an array field matches its elements as well
+func matches(typ reflect.Type, value any) bool {
+	if assignable(typ, value) {
+		return true
+	}
+	return isArray(typ) && assignable(typ.Elem(), value)
+}
+
+// assignable reports whether the value can be stored in the field, the numbers are interchangeable
+// and the documents are accepted by the struct and map fields
+func assignable(typ reflect.Type, value any) bool {
+	if value == nil {
+		return true
+	}
+	typ = indirect(typ)
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return true
+		}
+		rv = rv.Elem()
+	}
+	vt := rv.Type()
+	if typ.Kind() == reflect.Interface || vt.AssignableTo(typ) {
+		return true
+	}
+	switch value.(type) {
+	case bson.RawValue, bson.Null, bson.Undefined, bson.MinKey, bson.MaxKey:
+		return true
+	case bson.Regex:
+		return typ.Kind() == reflect.String
+	}
+	switch {
+	case typ == timeType || typ == dateTimeType:
+		return vt == timeType || vt == dateTimeType
+	case typ == decimalType || isNumber(typ):
+		return isNumber(vt) || vt == decimalType
+	case typ == objectIDType:
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.Struct, reflect.Map:
+		if _, ok := elements(rv.Interface()); ok {
+			return true
+		}
+		return vt.Kind() == reflect.Struct || vt.Kind() == reflect.Map
+	case reflect.Slice, reflect.Array:
+		if vt.Kind() != reflect.Slice && vt.Kind() != reflect.Array {
+			return false
+		}
+		for i := 0; i < rv.Len(); i++ {
+			if !assignable(typ.Elem(), rv.Index(i).Interface()) {
+				return false
+			}
+		}
+		return true
+	}
+	return vt.Kind() == typ.Kind()
+}
+
+func mismatch(op, key string, typ reflect.Type, value any) error {
+	return fmt.Errorf("%w: %s of %s expects %s, got %T", field.ErrTypeMismatch, op, key, typ, value)
+}
+
+// elements returns the elements of a document, false if the value isn't a document
+func elements(doc any) ([]bson.E, bool) {
+	switch d := doc.(type) {
+	case nil:
+		return nil, false
+	case bson.D:
+		return d, true
+	case bson.E:
+		return []bson.E{d}, true
+	case bson.Raw:
+		var elems bson.D
+		if err := bson.Unmarshal(d, &elems); err != nil {
+			return nil, false
+		}
+		return elems, true
+	}
+	rv := reflect.ValueOf(doc)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+	elems := make([]bson.E, 0, len(keys))
+	for _, key := range keys {
+		elems = append(elems, bson.E{Key: key.String(), Value: rv.MapIndex(key).Interface()})
+	}
+	return elems, true
+}
+
+// operators returns the elements of the value if it is an operator document, such as {$gt: 1}
+func operators(value any) ([]bson.E, bool) {
+	elems, ok := elements(value)
+	if !ok || len(elems) == 0 || !strings.HasPrefix(elems[0].Key, "$") {
+		return nil, false
+	}
+	return elems, true
+}
+
+func sliceValues(value any) ([]any, bool) {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	values := make([]any, rv.Len())
+	for i := range values {
+		values[i] = rv.Index(i).Interface()
+	}
+	return values, true
+}
+
+func indirect(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+// elemOf returns the element type of the array field, or the type of the field itself
+func elemOf(typ reflect.Type) reflect.Type {
+	if typ = indirect(typ); isArray(typ) {
+		return indirect(typ.Elem())
+	}
+	return typ
+}
+
+func isArray(typ reflect.Type) bool {
+	typ = indirect(typ)
+	if typ == objectIDType {
+		return false
+	}
+	return typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array
+}
+
+func isNumber(typ reflect.Type) bool {
+	typ = indirect(typ)
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return typ == decimalType
+}
+
+func isArrayElement(segment string) bool {
+	if strings.HasPrefix(segment, "$") {
+		return true
+	}
+	_, err := strconv.Atoi(segment)
+	return err == nil
+}
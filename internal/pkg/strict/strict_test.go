@@ -0,0 +1,210 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package strict
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type address struct {
+	City string `bson:"city"`
+}
+
+type item struct {
+	Name  string  `bson:"name"`
+	Price float64 `bson:"price"`
+}
+
+type base struct {
+	ID        bson.ObjectID `bson:"_id,omitempty"`
+	CreatedAt time.Time     `bson:"created_at"`
+}
+
+type user struct {
+	base     `bson:",inline"`
+	Name     string            `bson:"name"`
+	Age      int               `bson:"age"`
+	Nickname string            // stored as nickname by the driver
+	Tags     []string          `bson:"tags"`
+	Address  *address          `bson:"address"`
+	Items    []item            `bson:"items"`
+	Labels   map[string]string `bson:"labels"`
+	Extra    any               `bson:"extra"`
+	Ignored  string            `bson:"-"`
+}
+
+func TestExecute(t *testing.T) {
+	fields := field.ParseFields(user{})
+	testCases := []struct {
+		name    string
+		opCtx   *operation.OpContext
+		wantErr error
+	}{
+		{
+			name:  "nil fields",
+			opCtx: operation.NewOpContext(nil, operation.WithFilter(bson.D{{Key: "unknown", Value: 1}})),
+		},
+		{
+			name: "valid filter",
+			opCtx: operation.NewOpContext(nil, operation.WithFields(fields), operation.WithFilter(bson.D{
+				{Key: "_id", Value: bson.NewObjectID()},
+				{Key: "name", Value: "chenmingyong"},
+				{Key: "age", Value: bson.D{{Key: "$gte", Value: int64(18)}, {Key: "$lt", Value: 60.5}}},
+				{Key: "nickname", Value: bson.D{{Key: "$regex", Value: "^c"}}},
+				{Key: "tags", Value: "go"},
+				{Key: "tags.0", Value: bson.D{{Key: "$in", Value: []string{"go", "mongo"}}}},
+				{Key: "address.city", Value: "shenzhen"},
+				{Key: "items.price", Value: bson.M{"$gt": 10}},
+				{Key: "items", Value: bson.D{{Key: "$elemMatch", Value: bson.D{{Key: "name", Value: "book"}}}}},
+				{Key: "labels.env", Value: "prod"},
+				{Key: "extra.anything", Value: 1},
+				{Key: "created_at", Value: bson.D{{Key: "$lt", Value: time.Now()}}},
+				{Key: "$or", Value: bson.A{bson.M{"age": 1}, bson.M{"name": bson.M{"$exists": true}}}},
+				{Key: "$expr", Value: bson.D{{Key: "$gt", Value: bson.A{"$a", "$b"}}}},
+			})),
+		},
+		{
+			name:    "unknown field",
+			opCtx:   operation.NewOpContext(nil, operation.WithFields(fields), operation.WithFilter(bson.M{"nmae": "chenmingyong"})),
+			wantErr: field.ErrUnknownField,
+		},
+		{
+			name:    "ignored field",
+			opCtx:   operation.NewOpContext(nil, operation.WithFields(fields), operation.WithFilter(bson.M{"ignored": "chenmingyong"})),
+			wantErr: field.ErrUnknownField,
+		},
+		{
+			name:    "unknown nested field",
+			opCtx:   operation.NewOpContext(nil, operation.WithFields(fields), operation.WithFilter(bson.M{"address.street": "x"})),
+			wantErr: field.ErrUnknownField,
+		},
+		{
+			name:    "unknown field in $or",
+			opCtx:   operation.NewOpContext(nil, operation.WithFields(fields), operation.WithFilter(bson.M{"$or": bson.A{bson.M{"agee": 1}}})),
+			wantErr: field.ErrUnknownField,
+		},
+		{
+			name:    "unknown field in $elemMatch",
+			opCtx:   operation.NewOpContext(nil, operation.WithFields(fields), operation.WithFilter(bson.M{"items": bson.M{"$elemMatch": bson.M{"title": "book"}}})),
+			wantErr: field.ErrUnknownField,
+		},
+		{
+			name:    "string compared with number",
+			opCtx:   operation.NewOpContext(nil, operation.WithFields(fields), operation.WithFilter(bson.M{"age": bson.M{"$gt": "18"}})),
+			wantErr: field.ErrTypeMismatch,
+		},
+		{
+			name:    "mismatched element of $in",
+			opCtx:   operation.NewOpContext(nil, operation.WithFields(fields), operation.WithFilter(bson.M{"name": bson.M{"$in": bson.A{"a", 1}}})),
+			wantErr: field.ErrTypeMismatch,
+		},
+		{
+			name:    "$regex on a number",
+			opCtx:   operation.NewOpContext(nil, operation.WithFields(fields), operation.WithFilter(bson.M{"age": bson.M{"$regex": "1"}})),
+			wantErr: field.ErrTypeMismatch,
+		},
+		{
+			name:    "string compared with object id",
+			opCtx:   operation.NewOpContext(nil, operation.WithFields(fields), operation.WithFilter(bson.M{"_id": "65f0c1e2a1b2c3d4e5f60718"})),
+			wantErr: field.ErrTypeMismatch,
+		},
+		{
+			name: "valid sort and projection",
+			opCtx: operation.NewOpContext(nil, operation.WithFields(fields), operation.WithMongoOptions([]options.Lister[options.FindOptions]{
+				options.Find().SetSort(bson.D{{Key: "age", Value: -1}}).SetProjection(bson.M{"name": 1, "items.$": 1}),
+			})),
+		},
+		{
+			name: "unknown sort field",
+			opCtx: operation.NewOpContext(nil, operation.WithFields(fields), operation.WithMongoOptions([]options.Lister[options.FindOneOptions]{
+				options.FindOne().SetSort(bson.D{{Key: "agee", Value: -1}}),
+			})),
+			wantErr: field.ErrUnknownField,
+		},
+		{
+			name: "unknown projection field",
+			opCtx: operation.NewOpContext(nil, operation.WithFields(fields), operation.WithMongoOptions([]options.Lister[options.FindOneAndUpdateOptions]{
+				options.FindOneAndUpdate().SetProjection(bson.M{"password": 0}),
+			})),
+			wantErr: field.ErrUnknownField,
+		},
+		{
+			name: "valid updates",
+			opCtx: operation.NewOpContext(nil, operation.WithFields(fields), operation.WithUpdates(bson.D{
+				{Key: "$set", Value: bson.D{{Key: "name", Value: "chenmingyong"}, {Key: "address", Value: bson.M{"city": "shenzhen"}}, {Key: "items.$.price", Value: 1}}},
+				{Key: "$inc", Value: bson.M{"age": 1}},
+				{Key: "$currentDate", Value: bson.M{"created_at": true}},
+				{Key: "$push", Value: bson.M{"tags": bson.M{"$each": []string{"go"}}}},
+				{Key: "$addToSet", Value: bson.M{"items": item{Name: "book"}}},
+				{Key: "$pull", Value: bson.M{"items": bson.M{"price": bson.M{"$lt": 1}}}},
+				{Key: "$unset", Value: bson.M{"nickname": ""}},
+				{Key: "$rename", Value: bson.M{"extra": "nickname"}},
+			})),
+		},
+		{
+			name:    "unknown field of $set",
+			opCtx:   operation.NewOpContext(nil, operation.WithFields(fields), operation.WithUpdates(bson.M{"$set": bson.M{"nmae": "chenmingyong"}})),
+			wantErr: field.ErrUnknownField,
+		},
+		{
+			name:    "$inc on a string field",
+			opCtx:   operation.NewOpContext(nil, operation.WithFields(fields), operation.WithUpdates(bson.M{"$inc": bson.M{"name": 1}})),
+			wantErr: field.ErrTypeMismatch,
+		},
+		{
+			name:    "$set of an int into a time field",
+			opCtx:   operation.NewOpContext(nil, operation.WithFields(fields), operation.WithUpdates(bson.M{"$set": bson.M{"created_at": 1}})),
+			wantErr: field.ErrTypeMismatch,
+		},
+		{
+			name:    "$push to a non array field",
+			opCtx:   operation.NewOpContext(nil, operation.WithFields(fields), operation.WithUpdates(bson.M{"$push": bson.M{"name": "a"}})),
+			wantErr: field.ErrTypeMismatch,
+		},
+		{
+			name:    "$push of a mismatched element",
+			opCtx:   operation.NewOpContext(nil, operation.WithFields(fields), operation.WithUpdates(bson.M{"$push": bson.M{"tags": 1}})),
+			wantErr: field.ErrTypeMismatch,
+		},
+		{
+			name:    "$rename to an unknown field",
+			opCtx:   operation.NewOpContext(nil, operation.WithFields(fields), operation.WithUpdates(bson.M{"$rename": bson.M{"name": "full_name"}})),
+			wantErr: field.ErrUnknownField,
+		},
+		{
+			name:  "replacement is not checked",
+			opCtx: operation.NewOpContext(nil, operation.WithFields(fields), operation.WithUpdates(&user{Name: "chenmingyong"})),
+		},
+		{
+			name:  "pipeline is not checked",
+			opCtx: operation.NewOpContext(nil, operation.WithFields(fields), operation.WithUpdates(bson.A{bson.M{"$set": bson.M{"unknown": 1}}})),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Execute(context.Background(), tc.opCtx)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2023 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinErrors(t *testing.T) {
+	assert.Nil(t, JoinErrors())
+
+	err1, err2 := errors.New("err1"), errors.New("err2")
+	assert.Equal(t, err1, JoinErrors(err1))
+
+	err := JoinErrors(err1, err2)
+	assert.Equal(t, "err1\nerr2", err.Error())
+	assert.ErrorIs(t, err, err1)
+	assert.ErrorIs(t, err, err2)
+	assert.NotErrorIs(t, err, ErrInvalidArgument)
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import "go.mongodb.org/mongo-driver/v2/mongo/options"
+
+// MergeOptions returns the options set by the listers, the later ones overriding the former ones like the driver does.
+// The nil listers and setters are skipped.
+func MergeOptions[O any](opts ...options.Lister[O]) (*O, error) {
+	args := new(O)
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		for _, setter := range opt.List() {
+			if setter == nil {
+				continue
+			}
+			if err := setter(args); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return args, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type failedLister struct{}
+
+func (failedLister) List() []func(*options.FindOptions) error {
+	return []func(*options.FindOptions) error{nil, func(*options.FindOptions) error {
+		return errors.New("set error")
+	}}
+}
+
+func TestMergeOptions(t *testing.T) {
+	args, err := MergeOptions[options.FindOptions]()
+	require.NoError(t, err)
+	assert.Equal(t, &options.FindOptions{}, args)
+
+	args, err = MergeOptions[options.FindOptions](
+		options.Find().SetSort(bson.D{{Key: "age", Value: 1}}).SetLimit(10),
+		nil,
+		options.Find().SetLimit(20),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, bson.D{{Key: "age", Value: 1}}, args.Sort)
+	assert.Equal(t, ToPtr(int64(20)), args.Limit)
+
+	args, err = MergeOptions[options.FindOptions](options.Find().SetLimit(10), failedLister{})
+	assert.EqualError(t, err, "set error")
+	assert.Nil(t, args)
+}
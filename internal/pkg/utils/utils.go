@@ -103,3 +103,22 @@ func IsNumeric(value any) bool {
 		return false
 	}
 }
+
+// IsZero reports whether the value is nil or the zero value of its type
+func IsZero(value any) bool {
+	return value == nil || reflect.ValueOf(value).IsZero()
+}
+
+// IsNil reports whether the value is nil or a nil pointer, map, slice, func, channel or interface
+func IsNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package version
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/softdelete"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ErrConflict is returned when a write checking the version of the document matches no document,
+// which means the document has been modified by others or doesn't exist
+var ErrConflict = errors.New("mongox: version conflict, the document has been modified or doesn't exist")
+
+// ConflictError is the ErrConflict of a write, it carries the version the document was expected to have
+type ConflictError struct {
+	Expected any
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("mongox: version conflict, the document of version %v has been modified or doesn't exist", e.Expected)
+}
+
+// Is makes errors.Is(err, ErrConflict) report true
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// Field returns the version field of the model, nil if the model doesn't have one.
+func Field(fields []*field.Filed) *field.Filed {
+	for _, fd := range fields {
+		if fd.InlinedFields != nil {
+			if inlined := Field(fd.InlinedFields); inlined != nil {
+				return inlined
+			}
+			continue
+		}
+		if fd.Version {
+			return fd
+		}
+	}
+	return nil
+}
+
+// Scope adds the condition version == current to the filter without modifying the original one,
+// it follows the rules of softdelete.Scope
+func Scope(filter any, fd *field.Filed, current any) any {
+	return softdelete.Scope(filter, bson.E{Key: fd.MongoField, Value: current})
+}
+
+// Current returns the value of the version field of the document, which is a pointer to a struct.
+// It reports false if the model doesn't have a version field or the document isn't a struct.
+func Current(fields []*field.Filed, doc any) (any, bool) {
+	v := reflect.ValueOf(doc)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+	return current(v.Elem(), fields)
+}
+
+func current(dest reflect.Value, fields []*field.Filed) (any, bool) {
+	for idx, fd := range fields {
+		if fd.InlinedFields != nil {
+			if value, ok := current(dest.Field(idx), fd.InlinedFields); ok {
+				return value, true
+			}
+			continue
+		}
+		if fd.Version {
+			return dest.Field(idx).Interface(), true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package version
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type model struct {
+	Version int `bson:"version" mongox:"version"`
+}
+
+type user struct {
+	model `bson:",inline"`
+	Name  string `bson:"name"`
+}
+
+func TestField(t *testing.T) {
+	assert.Nil(t, Field(field.ParseFields(struct {
+		Name string `bson:"name"`
+	}{})))
+	assert.Equal(t, "version", Field(field.ParseFields(model{})).MongoField)
+	assert.Equal(t, "version", Field(field.ParseFields(user{})).MongoField)
+}
+
+func TestScope(t *testing.T) {
+	fd := Field(field.ParseFields(model{}))
+	assert.Equal(t, bson.D{{Key: "_id", Value: 1}, {Key: "version", Value: 2}}, Scope(bson.D{{Key: "_id", Value: 1}}, fd, 2))
+	assert.Equal(t, bson.M{"_id": 1, "version": 2}, Scope(bson.M{"_id": 1}, fd, 2))
+}
+
+func TestCurrent(t *testing.T) {
+	fields := field.ParseFields(user{})
+	testCases := []struct {
+		name   string
+		doc    any
+		want   any
+		wantOk bool
+	}{
+		{
+			name:   "inlined version field",
+			doc:    &user{model: model{Version: 3}},
+			want:   3,
+			wantOk: true,
+		},
+		{
+			name: "nil document",
+			doc:  (*user)(nil),
+		},
+		{
+			name: "not a pointer",
+			doc:  user{},
+		},
+		{
+			name: "not a struct",
+			doc:  &bson.M{},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := Current(fields, tc.doc)
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+	_, ok := Current(field.ParseFields(struct{ Name string }{}), &struct{ Name string }{})
+	assert.False(t, ok)
+}
+
+func TestConflictError(t *testing.T) {
+	var err error = &ConflictError{Expected: int64(2)}
+	assert.ErrorIs(t, err, ErrConflict)
+	assert.Equal(t, "mongox: version conflict, the document of version 2 has been modified or doesn't exist", err.Error())
+
+	var conflict *ConflictError
+	assert.True(t, errors.As(err, &conflict))
+	assert.Equal(t, int64(2), conflict.Expected)
+}
@@ -64,6 +64,26 @@ func (mr *MockIDeleterMockRecorder[T]) DeleteMany(ctx any, opts ...any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockIDeleter[T])(nil).DeleteMany), varargs...)
 }
 
+// DeleteManyReturning mocks base method.
+func (m *MockIDeleter[T]) DeleteManyReturning(ctx context.Context, opts ...options.Lister[options.DeleteManyOptions]) ([]*T, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteManyReturning", varargs...)
+	ret0, _ := ret[0].([]*T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteManyReturning indicates an expected call of DeleteManyReturning.
+func (mr *MockIDeleterMockRecorder[T]) DeleteManyReturning(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteManyReturning", reflect.TypeOf((*MockIDeleter[T])(nil).DeleteManyReturning), varargs...)
+}
+
 // DeleteOne mocks base method.
 func (m *MockIDeleter[T]) DeleteOne(ctx context.Context, opts ...options.Lister[options.DeleteOneOptions]) (*mongo.DeleteResult, error) {
 	m.ctrl.T.Helper()
@@ -112,6 +132,20 @@ func (mr *MockIDeleterMockRecorder[T]) GetCollection() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollection", reflect.TypeOf((*MockIDeleter[T])(nil).GetCollection))
 }
 
+// HardDelete mocks base method.
+func (m *MockIDeleter[T]) HardDelete() deleter.IDeleter[T] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDelete")
+	ret0, _ := ret[0].(deleter.IDeleter[T])
+	return ret0
+}
+
+// HardDelete indicates an expected call of HardDelete.
+func (mr *MockIDeleterMockRecorder[T]) HardDelete() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDelete", reflect.TypeOf((*MockIDeleter[T])(nil).HardDelete))
+}
+
 // ModelHook mocks base method.
 func (m *MockIDeleter[T]) ModelHook(modelHook any) deleter.IDeleter[T] {
 	m.ctrl.T.Helper()
@@ -189,3 +223,37 @@ func (mr *MockIDeleterMockRecorder[T]) RegisterBeforeHooks(hooks ...any) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterBeforeHooks", reflect.TypeOf((*MockIDeleter[T])(nil).RegisterBeforeHooks), hooks...)
 }
+
+// Restore mocks base method.
+func (m *MockIDeleter[T]) Restore(ctx context.Context, opts ...options.Lister[options.UpdateManyOptions]) (*mongo.UpdateResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Restore", varargs...)
+	ret0, _ := ret[0].(*mongo.UpdateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockIDeleterMockRecorder[T]) Restore(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockIDeleter[T])(nil).Restore), varargs...)
+}
+
+// Unscoped mocks base method.
+func (m *MockIDeleter[T]) Unscoped() deleter.IDeleter[T] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unscoped")
+	ret0, _ := ret[0].(deleter.IDeleter[T])
+	return ret0
+}
+
+// Unscoped indicates an expected call of Unscoped.
+func (mr *MockIDeleterMockRecorder[T]) Unscoped() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unscoped", reflect.TypeOf((*MockIDeleter[T])(nil).Unscoped))
+}
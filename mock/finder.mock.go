@@ -44,6 +44,20 @@ func (m *MockIFinder[T]) EXPECT() *MockIFinderMockRecorder[T] {
 	return m.recorder
 }
 
+// After mocks base method.
+func (m *MockIFinder[T]) After(token string) finder.IFinder[T] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "After", token)
+	ret0, _ := ret[0].(finder.IFinder[T])
+	return ret0
+}
+
+// After indicates an expected call of After.
+func (mr *MockIFinderMockRecorder[T]) After(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "After", reflect.TypeOf((*MockIFinder[T])(nil).After), token)
+}
+
 // Count mocks base method.
 func (m *MockIFinder[T]) Count(ctx context.Context, opts ...options.Lister[options.CountOptions]) (int64, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +78,20 @@ func (mr *MockIFinderMockRecorder[T]) Count(ctx any, opts ...any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockIFinder[T])(nil).Count), varargs...)
 }
 
+// CursorSecret mocks base method.
+func (m *MockIFinder[T]) CursorSecret(secret []byte) finder.IFinder[T] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CursorSecret", secret)
+	ret0, _ := ret[0].(finder.IFinder[T])
+	return ret0
+}
+
+// CursorSecret indicates an expected call of CursorSecret.
+func (mr *MockIFinderMockRecorder[T]) CursorSecret(secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CursorSecret", reflect.TypeOf((*MockIFinder[T])(nil).CursorSecret), secret)
+}
+
 // Distinct mocks base method.
 func (m *MockIFinder[T]) Distinct(ctx context.Context, fieldName string, opts ...options.Lister[options.DistinctOptions]) *mongo.DistinctResult {
 	m.ctrl.T.Helper()
@@ -102,6 +130,40 @@ func (mr *MockIFinderMockRecorder[T]) DistinctWithParse(ctx, fieldName, result a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DistinctWithParse", reflect.TypeOf((*MockIFinder[T])(nil).DistinctWithParse), varargs...)
 }
 
+// EstimatedCount mocks base method.
+func (m *MockIFinder[T]) EstimatedCount(ctx context.Context, opts ...options.Lister[options.EstimatedDocumentCountOptions]) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EstimatedCount", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimatedCount indicates an expected call of EstimatedCount.
+func (mr *MockIFinderMockRecorder[T]) EstimatedCount(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimatedCount", reflect.TypeOf((*MockIFinder[T])(nil).EstimatedCount), varargs...)
+}
+
+// FacetPagination mocks base method.
+func (m *MockIFinder[T]) FacetPagination() finder.IFinder[T] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FacetPagination")
+	ret0, _ := ret[0].(finder.IFinder[T])
+	return ret0
+}
+
+// FacetPagination indicates an expected call of FacetPagination.
+func (mr *MockIFinderMockRecorder[T]) FacetPagination() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FacetPagination", reflect.TypeOf((*MockIFinder[T])(nil).FacetPagination))
+}
+
 // Filter mocks base method.
 func (m *MockIFinder[T]) Filter(filter any) finder.IFinder[T] {
 	m.ctrl.T.Helper()
@@ -136,6 +198,25 @@ func (mr *MockIFinderMockRecorder[T]) Find(ctx any, opts ...any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIFinder[T])(nil).Find), varargs...)
 }
 
+// FindInBatches mocks base method.
+func (m *MockIFinder[T]) FindInBatches(ctx context.Context, size int, fn func([]*T) error, opts ...options.Lister[options.FindOptions]) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, size, fn}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindInBatches", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FindInBatches indicates an expected call of FindInBatches.
+func (mr *MockIFinderMockRecorder[T]) FindInBatches(ctx, size, fn any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, size, fn}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInBatches", reflect.TypeOf((*MockIFinder[T])(nil).FindInBatches), varargs...)
+}
+
 // FindOne mocks base method.
 func (m *MockIFinder[T]) FindOne(ctx context.Context, opts ...options.Lister[options.FindOneOptions]) (*T, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +237,46 @@ func (mr *MockIFinderMockRecorder[T]) FindOne(ctx any, opts ...any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOne", reflect.TypeOf((*MockIFinder[T])(nil).FindOne), varargs...)
 }
 
+// FindOneAndDelete mocks base method.
+func (m *MockIFinder[T]) FindOneAndDelete(ctx context.Context, opts ...options.Lister[options.FindOneAndDeleteOptions]) (*T, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindOneAndDelete", varargs...)
+	ret0, _ := ret[0].(*T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneAndDelete indicates an expected call of FindOneAndDelete.
+func (mr *MockIFinderMockRecorder[T]) FindOneAndDelete(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneAndDelete", reflect.TypeOf((*MockIFinder[T])(nil).FindOneAndDelete), varargs...)
+}
+
+// FindOneAndReplace mocks base method.
+func (m *MockIFinder[T]) FindOneAndReplace(ctx context.Context, opts ...options.Lister[options.FindOneAndReplaceOptions]) (*T, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FindOneAndReplace", varargs...)
+	ret0, _ := ret[0].(*T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindOneAndReplace indicates an expected call of FindOneAndReplace.
+func (mr *MockIFinderMockRecorder[T]) FindOneAndReplace(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneAndReplace", reflect.TypeOf((*MockIFinder[T])(nil).FindOneAndReplace), varargs...)
+}
+
 // FindOneAndUpdate mocks base method.
 func (m *MockIFinder[T]) FindOneAndUpdate(ctx context.Context, opts ...options.Lister[options.FindOneAndUpdateOptions]) (*T, error) {
 	m.ctrl.T.Helper()
@@ -190,6 +311,40 @@ func (mr *MockIFinderMockRecorder[T]) GetCollection() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollection", reflect.TypeOf((*MockIFinder[T])(nil).GetCollection))
 }
 
+// HardDelete mocks base method.
+func (m *MockIFinder[T]) HardDelete() finder.IFinder[T] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HardDelete")
+	ret0, _ := ret[0].(finder.IFinder[T])
+	return ret0
+}
+
+// HardDelete indicates an expected call of HardDelete.
+func (mr *MockIFinderMockRecorder[T]) HardDelete() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HardDelete", reflect.TypeOf((*MockIFinder[T])(nil).HardDelete))
+}
+
+// Iter mocks base method.
+func (m *MockIFinder[T]) Iter(ctx context.Context, opts ...options.Lister[options.FindOptions]) (*finder.Iterator[T], error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Iter", varargs...)
+	ret0, _ := ret[0].(*finder.Iterator[T])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Iter indicates an expected call of Iter.
+func (mr *MockIFinderMockRecorder[T]) Iter(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Iter", reflect.TypeOf((*MockIFinder[T])(nil).Iter), varargs...)
+}
+
 // Limit mocks base method.
 func (m *MockIFinder[T]) Limit(limit int64) finder.IFinder[T] {
 	m.ctrl.T.Helper()
@@ -218,6 +373,36 @@ func (mr *MockIFinderMockRecorder[T]) ModelHook(modelHook any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModelHook", reflect.TypeOf((*MockIFinder[T])(nil).ModelHook), modelHook)
 }
 
+// PageByCursor mocks base method.
+func (m *MockIFinder[T]) PageByCursor(ctx context.Context, size int64) (*finder.CursorPage[T], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PageByCursor", ctx, size)
+	ret0, _ := ret[0].(*finder.CursorPage[T])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PageByCursor indicates an expected call of PageByCursor.
+func (mr *MockIFinderMockRecorder[T]) PageByCursor(ctx, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PageByCursor", reflect.TypeOf((*MockIFinder[T])(nil).PageByCursor), ctx, size)
+}
+
+// Paginate mocks base method.
+func (m *MockIFinder[T]) Paginate(ctx context.Context, page int64, pageSize int64) (*finder.Page[T], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Paginate", ctx, page, pageSize)
+	ret0, _ := ret[0].(*finder.Page[T])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Paginate indicates an expected call of Paginate.
+func (mr *MockIFinderMockRecorder[T]) Paginate(ctx, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Paginate", reflect.TypeOf((*MockIFinder[T])(nil).Paginate), ctx, page, pageSize)
+}
+
 // PostActionHandler mocks base method.
 func (m *MockIFinder[T]) PostActionHandler(ctx context.Context, globalOpContext *operation.OpContext, opContext *finder.OpContext[T], opTypes ...operation.OpType) error {
 	m.ctrl.T.Helper()
@@ -256,6 +441,20 @@ func (mr *MockIFinderMockRecorder[T]) PreActionHandler(ctx, globalOpContext, opC
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreActionHandler", reflect.TypeOf((*MockIFinder[T])(nil).PreActionHandler), varargs...)
 }
 
+// Projection mocks base method.
+func (m *MockIFinder[T]) Projection(projection any) finder.IFinder[T] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Projection", projection)
+	ret0, _ := ret[0].(finder.IFinder[T])
+	return ret0
+}
+
+// Projection indicates an expected call of Projection.
+func (mr *MockIFinderMockRecorder[T]) Projection(projection any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Projection", reflect.TypeOf((*MockIFinder[T])(nil).Projection), projection)
+}
+
 // RegisterAfterHooks mocks base method.
 func (m *MockIFinder[T]) RegisterAfterHooks(hooks ...finder.AfterHookFn[T]) finder.IFinder[T] {
 	m.ctrl.T.Helper()
@@ -292,6 +491,20 @@ func (mr *MockIFinderMockRecorder[T]) RegisterBeforeHooks(hooks ...any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterBeforeHooks", reflect.TypeOf((*MockIFinder[T])(nil).RegisterBeforeHooks), hooks...)
 }
 
+// Replacement mocks base method.
+func (m *MockIFinder[T]) Replacement(replacement any) finder.IFinder[T] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replacement", replacement)
+	ret0, _ := ret[0].(finder.IFinder[T])
+	return ret0
+}
+
+// Replacement indicates an expected call of Replacement.
+func (mr *MockIFinderMockRecorder[T]) Replacement(replacement any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replacement", reflect.TypeOf((*MockIFinder[T])(nil).Replacement), replacement)
+}
+
 // Skip mocks base method.
 func (m *MockIFinder[T]) Skip(skip int64) finder.IFinder[T] {
 	m.ctrl.T.Helper()
@@ -320,6 +533,20 @@ func (mr *MockIFinderMockRecorder[T]) Sort(sort any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sort", reflect.TypeOf((*MockIFinder[T])(nil).Sort), sort)
 }
 
+// Unscoped mocks base method.
+func (m *MockIFinder[T]) Unscoped() finder.IFinder[T] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unscoped")
+	ret0, _ := ret[0].(finder.IFinder[T])
+	return ret0
+}
+
+// Unscoped indicates an expected call of Unscoped.
+func (mr *MockIFinderMockRecorder[T]) Unscoped() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unscoped", reflect.TypeOf((*MockIFinder[T])(nil).Unscoped))
+}
+
 // Updates mocks base method.
 func (m *MockIFinder[T]) Updates(update any) finder.IFinder[T] {
 	m.ctrl.T.Helper()
@@ -333,3 +560,17 @@ func (mr *MockIFinderMockRecorder[T]) Updates(update any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Updates", reflect.TypeOf((*MockIFinder[T])(nil).Updates), update)
 }
+
+// Version mocks base method.
+func (m *MockIFinder[T]) Version(current any) finder.IFinder[T] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Version", current)
+	ret0, _ := ret[0].(finder.IFinder[T])
+	return ret0
+}
+
+// Version indicates an expected call of Version.
+func (mr *MockIFinderMockRecorder[T]) Version(current any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockIFinder[T])(nil).Version), current)
+}
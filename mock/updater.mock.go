@@ -44,6 +44,24 @@ func (m *MockIUpdater[T]) EXPECT() *MockIUpdaterMockRecorder[T] {
 	return m.recorder
 }
 
+// ArrayFilters mocks base method.
+func (m *MockIUpdater[T]) ArrayFilters(filters ...any) updater.IUpdater[T] {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range filters {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ArrayFilters", varargs...)
+	ret0, _ := ret[0].(updater.IUpdater[T])
+	return ret0
+}
+
+// ArrayFilters indicates an expected call of ArrayFilters.
+func (mr *MockIUpdaterMockRecorder[T]) ArrayFilters(filters ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArrayFilters", reflect.TypeOf((*MockIUpdater[T])(nil).ArrayFilters), filters...)
+}
+
 // Filter mocks base method.
 func (m *MockIUpdater[T]) Filter(filter any) updater.IUpdater[T] {
 	m.ctrl.T.Helper()
@@ -150,6 +168,46 @@ func (mr *MockIUpdaterMockRecorder[T]) RegisterBeforeHooks(hooks ...any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterBeforeHooks", reflect.TypeOf((*MockIUpdater[T])(nil).RegisterBeforeHooks), hooks...)
 }
 
+// ReplaceOne mocks base method.
+func (m *MockIUpdater[T]) ReplaceOne(ctx context.Context, opts ...options.Lister[options.ReplaceOptions]) (*mongo.UpdateResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReplaceOne", varargs...)
+	ret0, _ := ret[0].(*mongo.UpdateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceOne indicates an expected call of ReplaceOne.
+func (mr *MockIUpdaterMockRecorder[T]) ReplaceOne(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceOne", reflect.TypeOf((*MockIUpdater[T])(nil).ReplaceOne), varargs...)
+}
+
+// ReplaceOrInsert mocks base method.
+func (m *MockIUpdater[T]) ReplaceOrInsert(ctx context.Context, opts ...options.Lister[options.ReplaceOptions]) (*mongo.UpdateResult, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReplaceOrInsert", varargs...)
+	ret0, _ := ret[0].(*mongo.UpdateResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceOrInsert indicates an expected call of ReplaceOrInsert.
+func (mr *MockIUpdaterMockRecorder[T]) ReplaceOrInsert(ctx any, opts ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceOrInsert", reflect.TypeOf((*MockIUpdater[T])(nil).ReplaceOrInsert), varargs...)
+}
+
 // Replacement mocks base method.
 func (m *MockIUpdater[T]) Replacement(replacement any) updater.IUpdater[T] {
 	m.ctrl.T.Helper()
@@ -164,6 +222,20 @@ func (mr *MockIUpdaterMockRecorder[T]) Replacement(replacement any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replacement", reflect.TypeOf((*MockIUpdater[T])(nil).Replacement), replacement)
 }
 
+// Unscoped mocks base method.
+func (m *MockIUpdater[T]) Unscoped() updater.IUpdater[T] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unscoped")
+	ret0, _ := ret[0].(updater.IUpdater[T])
+	return ret0
+}
+
+// Unscoped indicates an expected call of Unscoped.
+func (mr *MockIUpdaterMockRecorder[T]) Unscoped() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unscoped", reflect.TypeOf((*MockIUpdater[T])(nil).Unscoped))
+}
+
 // UpdateMany mocks base method.
 func (m *MockIUpdater[T]) UpdateMany(ctx context.Context, opts ...options.Lister[options.UpdateManyOptions]) (*mongo.UpdateResult, error) {
 	m.ctrl.T.Helper()
@@ -237,3 +309,17 @@ func (mr *MockIUpdaterMockRecorder[T]) Upsert(ctx any, opts ...any) *gomock.Call
 	varargs := append([]any{ctx}, opts...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockIUpdater[T])(nil).Upsert), varargs...)
 }
+
+// Version mocks base method.
+func (m *MockIUpdater[T]) Version(current any) updater.IUpdater[T] {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Version", current)
+	ret0, _ := ret[0].(updater.IUpdater[T])
+	return ret0
+}
+
+// Version indicates an expected call of Version.
+func (mr *MockIUpdaterMockRecorder[T]) Version(current any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Version", reflect.TypeOf((*MockIUpdater[T])(nil).Version), current)
+}
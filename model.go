@@ -24,6 +24,7 @@ import (
 // - ID: the primary key of the document
 // - CreatedAt: the time when the document was created
 // - UpdatedAt: the time when the document was last updated
+// - DeletedAt: the time when the document was soft deleted, documents with it set are excluded from queries
 // It may be embedded into a struct to provide these fields.
 // Example:
 //
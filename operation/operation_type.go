@@ -42,6 +42,10 @@ const (
 	OpTypeAfterFind       OpType = "afterFind"
 	OpTypeBeforeAggregate OpType = "beforeAggregate"
 	OpTypeAfterAggregate  OpType = "afterAggregate"
+	OpTypeBeforeCount     OpType = "beforeCount"
+	OpTypeAfterCount      OpType = "afterCount"
+	OpTypeBeforeDistinct  OpType = "beforeDistinct"
+	OpTypeAfterDistinct   OpType = "afterDistinct"
 	OpTypeBeforeAny       OpType = "before*"
 	OpTypeAfterAny        OpType = "after*"
 )
@@ -60,6 +64,8 @@ type OpContext struct {
 	ModelHook    any
 	ReflectValue reflect.Value
 	StartTime    time.Time
+	// session bound to the context of the operation, nil if the operation is not executed in a session
+	Session *mongo.Session
 
 	// result of the collection operation
 	Result any
@@ -133,6 +139,12 @@ func WithStartTime(startTime time.Time) OpContextOption {
 	}
 }
 
+func WithSession(session *mongo.Session) OpContextOption {
+	return func(opContext *OpContext) {
+		opContext.Session = session
+	}
+}
+
 func WithResult(result any) OpContextOption {
 	return func(opContext *OpContext) {
 		opContext.Result = result
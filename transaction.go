@@ -0,0 +1,141 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongox
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+	"go.mongodb.org/mongo-driver/v2/mongo/writeconcern"
+)
+
+type sessionOptions struct {
+	readConcern       *readconcern.ReadConcern
+	writeConcern      *writeconcern.WriteConcern
+	readPreference    *readpref.ReadPref
+	causalConsistency *bool
+}
+
+type SessionOption func(*sessionOptions)
+
+// WithReadConcern sets the read concern of the transactions in the session
+func WithReadConcern(readConcern *readconcern.ReadConcern) SessionOption {
+	return func(o *sessionOptions) {
+		o.readConcern = readConcern
+	}
+}
+
+// WithWriteConcern sets the write concern of the transactions in the session
+func WithWriteConcern(writeConcern *writeconcern.WriteConcern) SessionOption {
+	return func(o *sessionOptions) {
+		o.writeConcern = writeConcern
+	}
+}
+
+// WithReadPreference sets the read preference of the transactions in the session
+func WithReadPreference(readPreference *readpref.ReadPref) SessionOption {
+	return func(o *sessionOptions) {
+		o.readPreference = readPreference
+	}
+}
+
+// WithCausalConsistency sets whether the operations in the session are causally consistent
+func WithCausalConsistency(causalConsistency bool) SessionOption {
+	return func(o *sessionOptions) {
+		o.causalConsistency = &causalConsistency
+	}
+}
+
+func newSessionOptions(opts ...SessionOption) *sessionOptions {
+	o := &sessionOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *sessionOptions) sessionOptions() *options.SessionOptionsBuilder {
+	sessOpts := options.Session()
+	if o.causalConsistency != nil {
+		sessOpts.SetCausalConsistency(*o.causalConsistency)
+	}
+	txnOpts := o.transactionOptions()
+	if len(txnOpts.Opts) > 0 {
+		sessOpts.SetDefaultTransactionOptions(txnOpts)
+	}
+	return sessOpts
+}
+
+func (o *sessionOptions) transactionOptions() *options.TransactionOptionsBuilder {
+	txnOpts := options.Transaction()
+	if o.readConcern != nil {
+		txnOpts.SetReadConcern(o.readConcern)
+	}
+	if o.writeConcern != nil {
+		txnOpts.SetWriteConcern(o.writeConcern)
+	}
+	if o.readPreference != nil {
+		txnOpts.SetReadPreference(o.readPreference)
+	}
+	return txnOpts
+}
+
+// WithSession starts a session and executes fn with a context bound to it.
+// The builders executed with this context run in the session,
+// so that the reads observe the preceding writes when the session is causally consistent.
+// If ctx already carries a session, fn is executed in that session directly.
+func (c *Client) WithSession(ctx context.Context, fn func(ctx context.Context) error, opts ...SessionOption) error {
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+	return c.client.UseSessionWithOptions(ctx, newSessionOptions(opts...).sessionOptions(), fn)
+}
+
+// WithTransaction executes fn in a transaction and commits it if fn returns nil, otherwise the transaction is aborted.
+// The builders must be executed with the context passed to fn to take part in the transaction,
+// and the database callbacks can get the session from operation.OpContext.Session.
+// fn may be executed more than once because the transient transaction errors are retried.
+// If ctx already carries a session, fn joins the transaction running in that session,
+// or a transaction is started in that session when there is none, such as within WithSession.
+func (c *Client) WithTransaction(ctx context.Context, fn func(ctx context.Context) error, opts ...SessionOption) error {
+	sessOpts := newSessionOptions(opts...)
+	if sess := mongo.SessionFromContext(ctx); sess != nil {
+		return withTransaction(ctx, sess, fn, sessOpts.transactionOptions())
+	}
+	sess, err := c.client.StartSession(sessOpts.sessionOptions())
+	if err != nil {
+		return err
+	}
+	defer sess.EndSession(ctx)
+	return withTransaction(ctx, sess, fn, sessOpts.transactionOptions())
+}
+
+// withTransaction executes fn in a new transaction of the session, or in the transaction already running in it.
+// The driver doesn't expose whether a transaction is running,
+// so it is told by the error of starting the transaction before fn is called.
+func withTransaction(ctx context.Context, sess *mongo.Session, fn func(ctx context.Context) error, txnOpts *options.TransactionOptionsBuilder) error {
+	called := false
+	_, err := sess.WithTransaction(ctx, func(ctx context.Context) (any, error) {
+		called = true
+		return nil, fn(ctx)
+	}, txnOpts)
+	if !called && isTransactionInProgress(err) {
+		return fn(ctx)
+	}
+	return err
+}
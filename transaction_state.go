@@ -0,0 +1,28 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongox
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/v2/x/mongo/driver/session"
+)
+
+// isTransactionInProgress reports whether err is the error of starting a transaction in a session which already runs one.
+// mongo.Session doesn't expose the state of its transaction and the error is only declared by the experimental x package,
+// so the import is kept in this file and the behavior is pinned by TestIsTransactionInProgress.
+func isTransactionInProgress(err error) bool {
+	return errors.Is(err, session.ErrTransactInProgress)
+}
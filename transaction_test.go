@@ -0,0 +1,133 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mongox
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+	"go.mongodb.org/mongo-driver/v2/mongo/writeconcern"
+)
+
+func TestSessionOptions(t *testing.T) {
+	t.Run("empty options", func(t *testing.T) {
+		sessOpts := &options.SessionOptions{}
+		for _, set := range newSessionOptions().sessionOptions().List() {
+			require.NoError(t, set(sessOpts))
+		}
+		require.Equal(t, &options.SessionOptions{}, sessOpts)
+	})
+
+	t.Run("all options", func(t *testing.T) {
+		o := newSessionOptions(
+			WithReadConcern(readconcern.Majority()),
+			WithWriteConcern(writeconcern.Majority()),
+			WithReadPreference(readpref.Primary()),
+			WithCausalConsistency(false),
+		)
+
+		sessOpts := &options.SessionOptions{}
+		for _, set := range o.sessionOptions().List() {
+			require.NoError(t, set(sessOpts))
+		}
+		require.NotNil(t, sessOpts.CausalConsistency)
+		require.False(t, *sessOpts.CausalConsistency)
+		require.NotNil(t, sessOpts.DefaultTransactionOptions)
+
+		txnOpts := &options.TransactionOptions{}
+		for _, set := range o.transactionOptions().List() {
+			require.NoError(t, set(txnOpts))
+		}
+		require.Equal(t, readconcern.Majority(), txnOpts.ReadConcern)
+		require.Equal(t, writeconcern.Majority(), txnOpts.WriteConcern)
+		require.Equal(t, readpref.Primary(), txnOpts.ReadPreference)
+	})
+}
+
+func TestClient_WithTransaction_JoinSession(t *testing.T) {
+	mongoClient, err := mongo.Connect()
+	require.NoError(t, err)
+	client := NewClient(mongoClient, &Config{})
+
+	t.Run("join the running transaction", func(t *testing.T) {
+		sess, err := mongoClient.StartSession()
+		require.NoError(t, err)
+		defer sess.EndSession(context.Background())
+		require.NoError(t, sess.StartTransaction())
+		ctx := mongo.NewSessionContext(context.Background(), sess)
+
+		var got context.Context
+		err = client.WithTransaction(ctx, func(ctx context.Context) error {
+			got = ctx
+			return errors.New("fn error")
+		})
+		require.Equal(t, errors.New("fn error"), err)
+		require.Equal(t, ctx, got)
+		// the transaction is left to its owner
+		require.True(t, isTransactionInProgress(sess.StartTransaction()))
+	})
+
+	t.Run("start a transaction in the session", func(t *testing.T) {
+		sess, err := mongoClient.StartSession()
+		require.NoError(t, err)
+		defer sess.EndSession(context.Background())
+		ctx := mongo.NewSessionContext(context.Background(), sess)
+
+		called := 0
+		err = client.WithTransaction(ctx, func(ctx context.Context) error {
+			called++
+			require.Equal(t, sess, mongo.SessionFromContext(ctx))
+			require.True(t, isTransactionInProgress(sess.StartTransaction()))
+			return errors.New("fn error")
+		})
+		require.Equal(t, errors.New("fn error"), err)
+		require.Equal(t, 1, called)
+		// the transaction is aborted
+		require.NoError(t, sess.StartTransaction())
+	})
+}
+
+func TestClient_WithSession_JoinSession(t *testing.T) {
+	client := NewClient(&mongo.Client{}, &Config{})
+	ctx := mongo.NewSessionContext(context.Background(), &mongo.Session{})
+
+	var got context.Context
+	err := client.WithSession(ctx, func(ctx context.Context) error {
+		got = ctx
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, ctx, got)
+}
+
+func TestIsTransactionInProgress(t *testing.T) {
+	mongoClient, err := mongo.Connect()
+	require.NoError(t, err)
+	sess, err := mongoClient.StartSession()
+	require.NoError(t, err)
+	defer sess.EndSession(context.Background())
+
+	require.NoError(t, sess.StartTransaction())
+	// the driver reports the running transaction by the error of starting another one
+	require.True(t, isTransactionInProgress(sess.StartTransaction()))
+	require.False(t, isTransactionInProgress(nil))
+	require.False(t, isTransactionInProgress(errors.New("start transaction")))
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package updater
+
+import (
+	"context"
+	"time"
+
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/replace"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/version"
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
+
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// ReplaceOne replaces the document matching the filter with the replacement.
+// The before/after update callbacks and hooks are executed with the replacement as the updates,
+// so that its update time fields are refreshed. If the replacement is a T or a *T,
+// its zero _id and create time fields keep the values of the replaced document,
+// the document is then replaced by an update pipeline so that these values are kept in the same write.
+// With a version field, only the document of the version of the replacement is replaced,
+// ErrVersionConflict is returned if no document matches.
+func (u *Updater[T]) ReplaceOne(ctx context.Context, opts ...options.Lister[options.ReplaceOptions]) (*mongo.UpdateResult, error) {
+	return u.replace(ctx, u.scopedFilter(), false, opts)
+}
+
+// ReplaceOrInsert replaces the document matching the filter with the replacement, or inserts the replacement if no document matches.
+// The before/after upsert callbacks and hooks are executed with the replacement as the updates.
+// Like Upsert, it isn't limited to the documents that haven't been soft deleted.
+func (u *Updater[T]) ReplaceOrInsert(ctx context.Context, opts ...options.Lister[options.ReplaceOptions]) (*mongo.UpdateResult, error) {
+	opts = append(opts, options.Replace().SetUpsert(true))
+	return u.replace(ctx, u.filter, true, opts)
+}
+
+func (u *Updater[T]) replace(ctx context.Context, filter any, upsert bool, opts []options.Lister[options.ReplaceOptions]) (*mongo.UpdateResult, error) {
+	currentTime := time.Now()
+	replacement := u.replacement
+	// the callbacks modify a copy of a *T, which is copied back to the caller once it is written,
+	// so that a failed write doesn't leave the caller with an incremented version
+	var original *T
+	switch doc := replacement.(type) {
+	case T:
+		replacement = &doc
+	case *T:
+		if doc != nil {
+			original = doc
+			cp := *doc
+			replacement = &cp
+		}
+	}
+
+	var conflict error
+	var kept []string
+	if doc, ok := replacement.(*T); ok && doc != nil {
+		// the zero fields are found before the callbacks fill them for an upsert
+		kept = replace.Kept(u.fields, doc)
+		filter, conflict = u.versionedFilter(filter)
+		if fd := version.Field(u.fields); fd != nil && conflict == nil {
+			if current, ok := version.Current(u.fields, doc); ok {
+				filter, conflict = version.Scope(filter, fd, current), &VersionConflictError{Expected: current}
+			}
+		}
+	}
+
+	beforeOpType, afterOpType := operation.OpTypeBeforeUpdate, operation.OpTypeAfterUpdate
+	if upsert {
+		beforeOpType, afterOpType = operation.OpTypeBeforeUpsert, operation.OpTypeAfterUpsert
+	}
+	globalOpContext := operation.NewOpContext(u.collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithDoc(new(T)), operation.WithFilter(filter), operation.WithUpdates(replacement), operation.WithMongoOptions(opts), operation.WithModelHook(u.modelHook), operation.WithFields(u.fields), operation.WithStartTime(currentTime))
+	opContext := NewOpContext(u.collection, filter, replacement, WithReplacement(replacement), WithMongoOptions(opts), WithModelHook(u.modelHook), WithFields(u.fields), WithStartTime(currentTime))
+	err := u.PreActionHandler(ctx, globalOpContext, opContext, beforeOpType)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *mongo.UpdateResult
+	if len(kept) > 0 {
+		result, err = u.replaceKept(ctx, filter, replacement, kept, opts)
+	} else {
+		result, err = u.collection.ReplaceOne(ctx, filter, replacement, opts...)
+	}
+	if err != nil {
+		if upsert && conflict != nil && mongo.IsDuplicateKeyError(err) {
+			return nil, conflict
+		}
+		return nil, err
+	}
+	if !upsert && conflict != nil && result.MatchedCount == 0 {
+		return nil, conflict
+	}
+	if original != nil {
+		*original = *replacement.(*T)
+	}
+
+	globalOpContext.Result = result
+	opContext.Result = result
+	err = u.PostActionHandler(ctx, globalOpContext, opContext, afterOpType)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// replaceKept replaces the document by an update pipeline which keeps the stored values of the kept fields
+func (u *Updater[T]) replaceKept(ctx context.Context, filter, replacement any, kept []string, opts []options.Lister[options.ReplaceOptions]) (*mongo.UpdateResult, error) {
+	pipeline, err := replace.Pipeline(u.fields, replacement, kept)
+	if err != nil {
+		return nil, err
+	}
+	updateOpts, err := replaceUpdateOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return u.collection.UpdateOne(ctx, filter, pipeline, updateOpts)
+}
+
+// replaceUpdateOptions converts the options of ReplaceOne into the ones of UpdateOne
+func replaceUpdateOptions(opts []options.Lister[options.ReplaceOptions]) (*options.UpdateOneOptionsBuilder, error) {
+	args, err := utils.MergeOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	updateOpts := options.UpdateOne()
+	if args.BypassDocumentValidation != nil {
+		updateOpts.SetBypassDocumentValidation(*args.BypassDocumentValidation)
+	}
+	if args.Collation != nil {
+		updateOpts.SetCollation(args.Collation)
+	}
+	if args.Comment != nil {
+		updateOpts.SetComment(args.Comment)
+	}
+	if args.Hint != nil {
+		updateOpts.SetHint(args.Hint)
+	}
+	if args.Upsert != nil {
+		updateOpts.SetUpsert(*args.Upsert)
+	}
+	if args.Let != nil {
+		updateOpts.SetLet(args.Let)
+	}
+	if args.Sort != nil {
+		updateOpts.SetSort(args.Sort)
+	}
+	return updateOpts, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 chenmingyong0423
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+//     http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package updater
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chenmingyong0423/go-mongox/v2/callback"
+	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/utils"
+	"github.com/chenmingyong0423/go-mongox/v2/operation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+func Test_replaceUpdateOptions(t *testing.T) {
+	updateOpts, err := replaceUpdateOptions([]options.Lister[options.ReplaceOptions]{
+		options.Replace().SetUpsert(true).SetHint("name_1").SetComment("replace"),
+		nil,
+		options.Replace().SetBypassDocumentValidation(true).SetCollation(&options.Collation{Locale: "en"}).SetLet(bson.M{"x": 1}).SetSort(bson.D{{Key: "age", Value: 1}}),
+	})
+	assert.NoError(t, err)
+
+	args := new(options.UpdateOneOptions)
+	for _, setter := range updateOpts.List() {
+		assert.NoError(t, setter(args))
+	}
+	assert.Equal(t, &options.UpdateOneOptions{
+		BypassDocumentValidation: utils.ToPtr(true),
+		Collation:                &options.Collation{Locale: "en"},
+		Comment:                  "replace",
+		Hint:                     "name_1",
+		Upsert:                   utils.ToPtr(true),
+		Let:                      bson.M{"x": 1},
+		Sort:                     bson.D{{Key: "age", Value: 1}},
+	}, args)
+}
+
+type versionedUser struct {
+	ID      bson.ObjectID `bson:"_id,omitempty"`
+	Version int64         `bson:"version" mongox:"version"`
+}
+
+func TestUpdater_ReplaceOne_Version(t *testing.T) {
+	client, err := mongo.Connect()
+	require.NoError(t, err)
+	collection := client.Database("db-test").Collection("test_user")
+	errCallback := errors.New("callback error")
+
+	callbacks := callback.InitializeCallbacks()
+	var filter, updates any
+	callbacks.Register(operation.OpTypeBeforeUpdate, "test", func(_ context.Context, opCtx *operation.OpContext, _ ...any) error {
+		filter, updates = opCtx.Filter, opCtx.Updates
+		return errCallback
+	})
+	id := bson.NewObjectID()
+	replacement := &versionedUser{ID: id, Version: 2}
+	_, err = NewUpdater[versionedUser](collection, callbacks, field.ParseFields(versionedUser{})).Filter(bson.D{{Key: "_id", Value: id}}).Replacement(replacement).ReplaceOne(context.Background())
+	assert.Equal(t, errCallback, err)
+
+	assert.Equal(t, bson.D{{Key: "_id", Value: id}, {Key: "version", Value: int64(2)}}, filter)
+	assert.Equal(t, &versionedUser{ID: id, Version: 3}, updates)
+	// the replacement of the caller is only modified once it is written
+	assert.Equal(t, &versionedUser{ID: id, Version: 2}, replacement)
+}
@@ -19,6 +19,8 @@ import (
 	"time"
 
 	"github.com/chenmingyong0423/go-mongox/v2/field"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/softdelete"
+	"github.com/chenmingyong0423/go-mongox/v2/internal/pkg/version"
 
 	"github.com/chenmingyong0423/go-mongox/v2/callback"
 
@@ -28,6 +30,7 @@ import (
 
 	"github.com/chenmingyong0423/go-mongox/v2/operation"
 
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
@@ -37,12 +40,17 @@ type IUpdater[T any] interface {
 	UpdateOne(ctx context.Context, opts ...options.Lister[options.UpdateOneOptions]) (*mongo.UpdateResult, error)
 	UpdateMany(ctx context.Context, opts ...options.Lister[options.UpdateManyOptions]) (*mongo.UpdateResult, error)
 	Upsert(ctx context.Context, opts ...options.Lister[options.UpdateOneOptions]) (*mongo.UpdateResult, error)
+	ReplaceOne(ctx context.Context, opts ...options.Lister[options.ReplaceOptions]) (*mongo.UpdateResult, error)
+	ReplaceOrInsert(ctx context.Context, opts ...options.Lister[options.ReplaceOptions]) (*mongo.UpdateResult, error)
 	Filter(filter any) IUpdater[T]
+	ArrayFilters(filters ...any) IUpdater[T]
 	ModelHook(modelHook any) IUpdater[T]
 	RegisterAfterHooks(hooks ...AfterHookFn) IUpdater[T]
 	RegisterBeforeHooks(hooks ...BeforeHookFn) IUpdater[T]
 	Replacement(replacement any) IUpdater[T]
 	Updates(updates any) IUpdater[T]
+	Unscoped() IUpdater[T]
+	Version(current any) IUpdater[T]
 	PostActionHandler(ctx context.Context, globalOpContext *operation.OpContext, opContext *OpContext, opType operation.OpType) error
 	PreActionHandler(ctx context.Context, globalOpContext *operation.OpContext, opContext *OpContext, opType operation.OpType) error
 	GetCollection() *mongo.Collection
@@ -54,6 +62,13 @@ func NewUpdater[T any](collection *mongo.Collection, dbCallbacks *callback.Callb
 
 var _ IUpdater[any] = (*Updater[any])(nil)
 
+// ErrVersionConflict is returned when the update checking the version matches no document,
+// the returned error is a *VersionConflictError, so it should be checked by errors.Is
+var ErrVersionConflict = version.ErrConflict
+
+// VersionConflictError is the ErrVersionConflict carrying the expected version, it can be got by errors.As
+type VersionConflictError = version.ConflictError
+
 type Updater[T any] struct {
 	collection *mongo.Collection
 	fields     []*field.Filed
@@ -61,7 +76,12 @@ type Updater[T any] struct {
 	filter      any
 	updates     any
 	replacement any
-	modelHook   any
+	// arrayFilters are the filters of the elements updated through $[<identifier>]
+	arrayFilters []any
+	modelHook    any
+	unscoped     bool
+	// version is the current version of the document expected by the update
+	version any
 
 	DBCallbacks *callback.Callback
 	BeforeHooks []BeforeHookFn
@@ -80,6 +100,38 @@ func (u *Updater[T]) Updates(updates any) IUpdater[T] {
 	return u
 }
 
+// ArrayFilters sets the filters of the array elements updated through the filtered positional operator,
+// such as bson.D{{Key: "elem.qty", Value: bson.D{{Key: "$lte", Value: 0}}}} for "items.$[elem].qty".
+// A filter can be built by query.Builder, whose invalid arguments reported by BuildE fail the update.
+func (u *Updater[T]) ArrayFilters(filters ...any) IUpdater[T] {
+	u.arrayFilters = filters
+	return u
+}
+
+// buildArrayFilters builds the array filters set by ArrayFilters, nil if there is none
+func (u *Updater[T]) buildArrayFilters() ([]any, error) {
+	if len(u.arrayFilters) == 0 {
+		return nil, nil
+	}
+	filters := make([]any, 0, len(u.arrayFilters))
+	for _, filter := range u.arrayFilters {
+		switch f := filter.(type) {
+		case interface{ BuildE() (bson.D, error) }:
+			d, err := f.BuildE()
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, d)
+		case interface{ Build() bson.D }:
+			filters = append(filters, f.Build())
+		default:
+			filters = append(filters, filter)
+		}
+	}
+	return filters, nil
+}
+
+// Replacement is used to set the replacement of ReplaceOne and ReplaceOrInsert
 func (u *Updater[T]) Replacement(replacement any) IUpdater[T] {
 	u.replacement = replacement
 	return u
@@ -90,6 +142,39 @@ func (u *Updater[T]) ModelHook(modelHook any) IUpdater[T] {
 	return u
 }
 
+// Unscoped makes the update also apply to the soft deleted documents
+func (u *Updater[T]) Unscoped() IUpdater[T] {
+	u.unscoped = true
+	return u
+}
+
+// Version makes UpdateOne, Upsert and the replacements only update the document whose version field equals current,
+// ErrVersionConflict is returned if no document matches. The replacements use the version of the replacement by default.
+// The version field is incremented by every update whether Version is called or not.
+func (u *Updater[T]) Version(current any) IUpdater[T] {
+	u.version = current
+	return u
+}
+
+// versionedFilter adds the version condition to the filter if the model has a version field and Version is called,
+// the returned conflict is the error of a write matching no document, nil if the filter isn't versioned
+func (u *Updater[T]) versionedFilter(filter any) (any, error) {
+	if u.version == nil {
+		return filter, nil
+	}
+	fd := version.Field(u.fields)
+	if fd == nil {
+		return filter, nil
+	}
+	return version.Scope(filter, fd, u.version), &VersionConflictError{Expected: u.version}
+}
+
+// scopedFilter returns the filter sent to the collection,
+// which excludes the soft deleted documents unless Unscoped is called
+func (u *Updater[T]) scopedFilter() any {
+	return softdelete.ScopeLive(u.fields, u.filter, u.unscoped)
+}
+
 func (u *Updater[T]) RegisterBeforeHooks(hooks ...BeforeHookFn) IUpdater[T] {
 	u.BeforeHooks = append(u.BeforeHooks, hooks...)
 	return u
@@ -131,23 +216,34 @@ func (u *Updater[T]) PostActionHandler(ctx context.Context, globalOpContext *ope
 func (u *Updater[T]) UpdateOne(ctx context.Context, opts ...options.Lister[options.UpdateOneOptions]) (*mongo.UpdateResult, error) {
 
 	currentTime := time.Now()
+	filter, conflict := u.versionedFilter(u.scopedFilter())
+	arrayFilters, err := u.buildArrayFilters()
+	if err != nil {
+		return nil, err
+	}
+	if arrayFilters != nil {
+		opts = append(opts, options.UpdateOne().SetArrayFilters(arrayFilters))
+	}
 
 	updates := bsonx.ToBsonM(u.updates)
 	if len(updates) != 0 {
 		u.updates = updates
 	}
 
-	globalOpContext := operation.NewOpContext(u.collection, operation.WithDoc(new(T)), operation.WithFilter(u.filter), operation.WithUpdates(u.updates), operation.WithMongoOptions(opts), operation.WithModelHook(u.modelHook), operation.WithFields(u.fields), operation.WithStartTime(currentTime))
-	opContext := NewOpContext(u.collection, u.filter, u.updates, WithMongoOptions(opts), WithModelHook(u.modelHook), WithFields(u.fields), WithStartTime(currentTime))
-	err := u.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeUpdate)
+	globalOpContext := operation.NewOpContext(u.collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithDoc(new(T)), operation.WithFilter(filter), operation.WithUpdates(u.updates), operation.WithMongoOptions(opts), operation.WithModelHook(u.modelHook), operation.WithFields(u.fields), operation.WithStartTime(currentTime))
+	opContext := NewOpContext(u.collection, filter, u.updates, WithMongoOptions(opts), WithModelHook(u.modelHook), WithFields(u.fields), WithStartTime(currentTime))
+	err = u.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeUpdate)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := u.collection.UpdateOne(ctx, u.filter, u.updates, opts...)
+	result, err := u.collection.UpdateOne(ctx, filter, globalOpContext.Updates, opts...)
 	if err != nil {
 		return nil, err
 	}
+	if conflict != nil && result.MatchedCount == 0 {
+		return nil, conflict
+	}
 
 	globalOpContext.Result = result
 	opContext.Result = result
@@ -160,21 +256,29 @@ func (u *Updater[T]) UpdateOne(ctx context.Context, opts ...options.Lister[optio
 
 func (u *Updater[T]) UpdateMany(ctx context.Context, opts ...options.Lister[options.UpdateManyOptions]) (*mongo.UpdateResult, error) {
 	currentTime := time.Now()
+	filter := u.scopedFilter()
+	arrayFilters, err := u.buildArrayFilters()
+	if err != nil {
+		return nil, err
+	}
+	if arrayFilters != nil {
+		opts = append(opts, options.UpdateMany().SetArrayFilters(arrayFilters))
+	}
 
 	updates := bsonx.ToBsonM(u.updates)
 	if len(updates) != 0 {
 		u.updates = updates
 	}
 
-	globalOpContext := operation.NewOpContext(u.collection, operation.WithDoc(new(T)), operation.WithFilter(u.filter), operation.WithUpdates(u.updates), operation.WithMongoOptions(opts), operation.WithModelHook(u.modelHook), operation.WithFields(u.fields), operation.WithStartTime(currentTime))
-	opContext := NewOpContext(u.collection, u.filter, u.updates, WithMongoOptions(opts), WithModelHook(u.modelHook), WithFields(u.fields), WithStartTime(currentTime))
+	globalOpContext := operation.NewOpContext(u.collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithDoc(new(T)), operation.WithFilter(filter), operation.WithUpdates(u.updates), operation.WithMongoOptions(opts), operation.WithModelHook(u.modelHook), operation.WithFields(u.fields), operation.WithStartTime(currentTime))
+	opContext := NewOpContext(u.collection, filter, u.updates, WithMongoOptions(opts), WithModelHook(u.modelHook), WithFields(u.fields), WithStartTime(currentTime))
 
-	err := u.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeUpdate)
+	err = u.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeUpdate)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := u.collection.UpdateMany(ctx, u.filter, u.updates, opts...)
+	result, err := u.collection.UpdateMany(ctx, filter, globalOpContext.Updates, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -188,6 +292,10 @@ func (u *Updater[T]) UpdateMany(ctx context.Context, opts ...options.Lister[opti
 	return result, nil
 }
 
+// Upsert is not limited to the documents that haven't been soft deleted,
+// otherwise a soft deleted document matching the filter would be inserted again.
+// With Version, a document whose version differs can't be matched and is inserted instead,
+// so the filter should contain _id or a unique key to turn the insertion into ErrVersionConflict.
 func (u *Updater[T]) Upsert(ctx context.Context, opts ...options.Lister[options.UpdateOneOptions]) (*mongo.UpdateResult, error) {
 	currentTime := time.Now()
 
@@ -202,20 +310,33 @@ func (u *Updater[T]) Upsert(ctx context.Context, opts ...options.Lister[options.
 		}
 	}
 
+	arrayFilters, err := u.buildArrayFilters()
+	if err != nil {
+		return nil, err
+	}
+	if arrayFilters != nil {
+		opts = append(opts, options.UpdateOne().SetArrayFilters(arrayFilters))
+	}
+
+	filter, conflict := u.versionedFilter(u.filter)
 	updates := bsonx.ToBsonM(u.updates)
 	if len(updates) != 0 {
 		u.updates = updates
 	}
 
-	globalOpContext := operation.NewOpContext(u.collection, operation.WithDoc(new(T)), operation.WithFilter(u.filter), operation.WithUpdates(u.updates), operation.WithMongoOptions(opts), operation.WithModelHook(u.modelHook), operation.WithStartTime(currentTime), operation.WithFields(u.fields))
-	opContext := NewOpContext(u.collection, u.filter, u.updates, WithMongoOptions(opts), WithModelHook(u.modelHook), WithStartTime(currentTime), WithFields(u.fields))
-	err := u.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeUpsert)
+	globalOpContext := operation.NewOpContext(u.collection, operation.WithSession(mongo.SessionFromContext(ctx)), operation.WithDoc(new(T)), operation.WithFilter(filter), operation.WithUpdates(u.updates), operation.WithMongoOptions(opts), operation.WithModelHook(u.modelHook), operation.WithStartTime(currentTime), operation.WithFields(u.fields))
+	opContext := NewOpContext(u.collection, filter, u.updates, WithMongoOptions(opts), WithModelHook(u.modelHook), WithStartTime(currentTime), WithFields(u.fields))
+	err = u.PreActionHandler(ctx, globalOpContext, opContext, operation.OpTypeBeforeUpsert)
 	if err != nil {
 		return nil, err
 	}
 
-	result, err := u.collection.UpdateOne(ctx, u.filter, u.updates, opts...)
+	result, err := u.collection.UpdateOne(ctx, filter, globalOpContext.Updates, opts...)
 	if err != nil {
+		// the document exists with another version, so inserting it again violates the unique _id
+		if conflict != nil && mongo.IsDuplicateKeyError(err) {
+			return nil, conflict
+		}
 		return nil, err
 	}
 
@@ -33,6 +33,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 
+	"github.com/chenmingyong0423/go-mongox/v2/builder/aggregation"
 	"github.com/chenmingyong0423/go-mongox/v2/builder/query"
 	"github.com/chenmingyong0423/go-mongox/v2/builder/update"
 	xupdater "github.com/chenmingyong0423/go-mongox/v2/updater"
@@ -1400,3 +1401,204 @@ func TestUpdater_e2e_Upsert(t *testing.T) {
 		})
 	}
 }
+
+type versionedUser struct {
+	ID      bson.ObjectID `bson:"_id,omitempty"`
+	Name    string        `bson:"name"`
+	Version int64         `bson:"version" mongox:"version"`
+}
+
+func TestUpdater_e2e_Version(t *testing.T) {
+	collection := getCollection(t)
+	ctx := context.Background()
+	id := bson.NewObjectID()
+	_, err := collection.InsertOne(ctx, &versionedUser{ID: id, Name: "cmy"})
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Build())
+		require.NoError(t, err)
+	}()
+
+	newUpdater := func() *xupdater.Updater[versionedUser] {
+		return xupdater.NewUpdater[versionedUser](collection, callback.InitializeCallbacks(), field.ParseFields(versionedUser{}))
+	}
+
+	result, err := newUpdater().Filter(query.Id(id)).Version(int64(0)).Updates(update.Set("name", "chenmingyong")).UpdateOne(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.ModifiedCount)
+
+	// the version has been incremented by the previous update
+	_, err = newUpdater().Filter(query.Id(id)).Version(int64(0)).Updates(update.Set("name", "Mingyong Chen")).UpdateOne(ctx)
+	require.ErrorIs(t, err, xupdater.ErrVersionConflict)
+	var conflict *xupdater.VersionConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.Equal(t, int64(0), conflict.Expected)
+
+	_, err = newUpdater().Filter(query.Id(id)).Version(int64(0)).Updates(update.Set("name", "Mingyong Chen")).Upsert(ctx)
+	require.ErrorIs(t, err, xupdater.ErrVersionConflict)
+
+	result, err = newUpdater().Filter(query.Id(id)).Version(int64(1)).Updates(update.Set("name", "Mingyong Chen")).Upsert(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.MatchedCount)
+
+	user := new(versionedUser)
+	require.NoError(t, collection.FindOne(ctx, query.Id(id)).Decode(user))
+	require.Equal(t, &versionedUser{ID: id, Name: "Mingyong Chen", Version: 2}, user)
+
+	// the stale replacement isn't written, so its version isn't incremented
+	replacement := &versionedUser{ID: id, Name: "cmy", Version: 1}
+	_, err = newUpdater().Filter(query.Id(id)).Replacement(replacement).ReplaceOne(ctx)
+	require.ErrorAs(t, err, &conflict)
+	require.Equal(t, int64(1), conflict.Expected)
+	require.Equal(t, int64(1), replacement.Version)
+
+	replacement.Version = 2
+	_, err = newUpdater().Filter(query.Id(id)).Replacement(replacement).ReplaceOne(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), replacement.Version)
+}
+
+func TestUpdater_e2e_Pipeline(t *testing.T) {
+	collection := getCollection(t)
+	ctx := context.Background()
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Build())
+		require.NoError(t, err)
+	}()
+
+	newUpdater := func() *xupdater.Updater[TestUser] {
+		return xupdater.NewUpdater[TestUser](collection, callback.InitializeCallbacks(), field.ParseFields(TestUser{}))
+	}
+
+	// the inserted document gets the create and update times like $setOnInsert and $set do
+	result, err := newUpdater().Filter(query.Eq("name", "cmy")).
+		Updates(aggregation.NewStageBuilder().Set(bson.D{{Key: "age", Value: 18}}).Build()).
+		Upsert(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.UpsertedCount)
+
+	inserted := new(TestUser)
+	require.NoError(t, collection.FindOne(ctx, query.Eq("name", "cmy")).Decode(inserted))
+	require.Equal(t, int64(18), inserted.Age)
+	require.False(t, inserted.CreatedAt.IsZero())
+	require.False(t, inserted.UpdatedAt.IsZero())
+	require.NotZero(t, inserted.CreateSecondTime)
+	require.NotZero(t, inserted.UpdateMilliTime)
+
+	time.Sleep(10 * time.Millisecond)
+	result, err = newUpdater().Filter(query.Eq("name", "cmy")).
+		Updates(aggregation.NewStageBuilder().Unset("age").Build()).
+		UpdateOne(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.ModifiedCount)
+
+	updated := new(TestUser)
+	require.NoError(t, collection.FindOne(ctx, query.Eq("name", "cmy")).Decode(updated))
+	require.Zero(t, updated.Age)
+	require.Equal(t, inserted.CreatedAt, updated.CreatedAt)
+	require.True(t, updated.UpdatedAt.After(inserted.UpdatedAt))
+}
+
+type order struct {
+	ID    bson.ObjectID `bson:"_id"`
+	Items []orderItem   `bson:"items"`
+}
+
+type orderItem struct {
+	Name string `bson:"name"`
+	Qty  int    `bson:"qty"`
+}
+
+func TestUpdater_e2e_ArrayFilters(t *testing.T) {
+	collection := getCollection(t)
+	ctx := context.Background()
+	id := bson.NewObjectID()
+	_, err := collection.InsertOne(ctx, &order{ID: id, Items: []orderItem{{Name: "pen", Qty: 0}, {Name: "book", Qty: 5}, {Name: "bag", Qty: -1}}})
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Build())
+		require.NoError(t, err)
+	}()
+
+	newUpdater := func() *xupdater.Updater[order] {
+		return xupdater.NewUpdater[order](collection, callback.InitializeCallbacks(), field.ParseFields(order{}))
+	}
+
+	_, err = newUpdater().Filter(query.Id(id)).
+		Updates(update.Set(update.Filtered("items", "elem", "qty"), 10)).
+		ArrayFilters(query.NewBuilder().Lte("elem.qty", 0)).
+		UpdateOne(ctx)
+	require.NoError(t, err)
+
+	_, err = newUpdater().Filter(query.NewBuilder().Id(id).Eq("items.name", "book").Build()).
+		Updates(update.Inc(update.Positional("items", "qty"), 1)).
+		UpdateOne(ctx)
+	require.NoError(t, err)
+
+	_, err = newUpdater().Filter(query.Id(id)).
+		Updates(update.Set(update.AllPositional("items", "name"), "gift")).
+		UpdateMany(ctx)
+	require.NoError(t, err)
+
+	got := new(order)
+	require.NoError(t, collection.FindOne(ctx, query.Id(id)).Decode(got))
+	assert.Equal(t, []orderItem{{Name: "gift", Qty: 10}, {Name: "gift", Qty: 6}, {Name: "gift", Qty: 10}}, got.Items)
+}
+
+type replaceUser struct {
+	ID        bson.ObjectID `bson:"_id"`
+	Name      string        `bson:"name"`
+	CreatedAt time.Time     `bson:"created_at"`
+	UpdatedAt time.Time     `bson:"updated_at"`
+}
+
+func (u *replaceUser) BeforeUpdate(_ context.Context) error {
+	u.Name = "hooked " + u.Name
+	return nil
+}
+
+func TestUpdater_e2e_ReplaceOne(t *testing.T) {
+	collection := getCollection(t)
+	ctx := context.Background()
+	createdAt := time.Now().Add(-time.Hour).Truncate(time.Millisecond).UTC()
+	id := bson.NewObjectID()
+	_, err := collection.InsertOne(ctx, &replaceUser{ID: id, Name: "cmy", CreatedAt: createdAt, UpdatedAt: createdAt})
+	require.NoError(t, err)
+	defer func() {
+		_, err := collection.DeleteMany(ctx, query.NewBuilder().Build())
+		require.NoError(t, err)
+	}()
+
+	var opTypes []operation.OpType
+	callbacks := callback.InitializeCallbacks()
+	for _, opType := range []operation.OpType{operation.OpTypeBeforeUpdate, operation.OpTypeAfterUpdate} {
+		opType := opType
+		callbacks.Register(opType, "record", func(_ context.Context, _ *operation.OpContext, _ ...any) error {
+			opTypes = append(opTypes, opType)
+			return nil
+		})
+	}
+	replacement := &replaceUser{Name: "chenmingyong"}
+	result, err := xupdater.NewUpdater[replaceUser](collection, callbacks, field.ParseFields(replaceUser{})).
+		Filter(query.Eq("name", "cmy")).Replacement(replacement).ReplaceOne(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.ModifiedCount)
+	require.Equal(t, []operation.OpType{operation.OpTypeBeforeUpdate, operation.OpTypeAfterUpdate}, opTypes)
+
+	user := new(replaceUser)
+	require.NoError(t, collection.FindOne(ctx, query.Id(id)).Decode(user))
+	require.Equal(t, id, user.ID)
+	require.Equal(t, "hooked chenmingyong", user.Name)
+	require.Equal(t, createdAt, user.CreatedAt.UTC())
+	require.True(t, user.UpdatedAt.After(createdAt))
+
+	result, err = xupdater.NewUpdater[replaceUser](collection, callback.InitializeCallbacks(), field.ParseFields(replaceUser{})).
+		Filter(query.Eq("name", "Mingyong Chen")).Replacement(replaceUser{ID: bson.NewObjectID(), Name: "Mingyong Chen"}).ReplaceOrInsert(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), result.UpsertedCount)
+
+	inserted := new(replaceUser)
+	require.NoError(t, collection.FindOne(ctx, query.Id(result.UpsertedID)).Decode(inserted))
+	require.False(t, inserted.CreatedAt.IsZero())
+	require.False(t, inserted.UpdatedAt.IsZero())
+}
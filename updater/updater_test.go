@@ -18,6 +18,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/chenmingyong0423/go-mongox/v2/builder/query"
 	mocks "github.com/chenmingyong0423/go-mongox/v2/mock"
 	"github.com/chenmingyong0423/go-mongox/v2/operation"
 	"github.com/chenmingyong0423/go-mongox/v2/updater"
@@ -325,6 +326,29 @@ func TestUpdater_Filter(t *testing.T) {
 	}
 }
 
+func TestUpdater_Version(t *testing.T) {
+	ctl := gomock.NewController(t)
+	u := mocks.NewMockIUpdater[any](ctl)
+	u.EXPECT().Version(int64(1)).Return(u).Times(1)
+
+	result := u.Version(int64(1))
+	assert.Equal(t, u, result)
+}
+
+func TestUpdater_ArrayFilters(t *testing.T) {
+	ctl := gomock.NewController(t)
+	u := mocks.NewMockIUpdater[any](ctl)
+	filter := query.NewBuilder().Lte("elem.qty", 0)
+	u.EXPECT().ArrayFilters(filter).Return(u).Times(1)
+
+	result := u.ArrayFilters(filter)
+	assert.Equal(t, u, result)
+
+	// the invalid arguments of the builders fail the update before it is sent
+	_, err := updater.NewUpdater[any](&mongo.Collection{}, nil, nil).ArrayFilters(query.NewBuilder().In("elem.qty")).UpdateOne(context.Background())
+	assert.ErrorIs(t, err, query.ErrInvalidArgument)
+}
+
 func TestUpdater_Updates(t *testing.T) {
 	testCases := []struct {
 		name    string